@@ -1,30 +1,84 @@
 package main
 
 import (
+	"flag"
 	"fmt"
+	"os"
 	"strconv"
 
 	"github.com/stephen-condon/advent-of-code-2025/utilities"
 )
 
 func main() {
+	bench := utilities.AddBenchFlags(flag.CommandLine)
+	profile := utilities.AddProfileFlags(flag.CommandLine)
+	selection := utilities.AddSelectionFlags(flag.CommandLine)
+	flag.Parse()
+
+	cases, err := utilities.LoadCases(".")
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	if bench.Enabled() {
+		if err := bench.Run("day1", cases, benchParts); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	// Exit non-zero if any part fails, so that run-all and scripts notice
+	failed := false
+
 	fmt.Println("Part One:")
-	executePartOne("example.txt")
-	executePartOne("input.txt")
+	for _, c := range selection.Cases(cases, 1) {
+		answer := profile.Run("day1", c, 1, func() string {
+			return executePartOne(c.File)
+		})
+		if answer == "" || !utilities.CheckAnswer(c, 1, answer) {
+			failed = true
+		}
+	}
 
 	fmt.Println("\nPart Two:")
-	executePartTwo("example.txt")
-	executePartTwo("input.txt")
+	for _, c := range selection.Cases(cases, 2) {
+		answer := profile.Run("day1", c, 2, func() string {
+			return executePartTwo(c.File)
+		})
+		if answer == "" || !utilities.CheckAnswer(c, 2, answer) {
+			failed = true
+		}
+	}
+
+	if failed {
+		os.Exit(1)
+	}
 }
 
-func executePartOne(filename string) {
-	input := utilities.LoadInput(filename)
+func executePartOne(filename string) string {
+	commands := parseCommands(utilities.LoadInput(filename))
+	numZeroes := countZeroStops(commands)
+
+	fmt.Printf("%s: %d\n", filename, numZeroes)
+	return strconv.Itoa(numZeroes)
+}
+
+func executePartTwo(filename string) string {
+	commands := parseCommands(utilities.LoadInput(filename))
+	numZeroes := countZeroPasses(commands)
+
+	fmt.Printf("%s: %d\n", filename, numZeroes)
+	return strconv.Itoa(numZeroes)
+}
+
+// countZeroStops counts the rotations that leave the dial at 0
+func countZeroStops(commands []*Command) int {
 	position := 50
 	numZeroes := 0
 
-	for _, line := range input {
-		command := parseCommand(line)
-
+	for _, command := range commands {
 		switch command.Direction {
 		case "L":
 			position = (position - command.Steps + 100) % 100
@@ -37,17 +91,15 @@ func executePartOne(filename string) {
 		}
 	}
 
-	fmt.Println(numZeroes)
+	return numZeroes
 }
 
-func executePartTwo(filename string) {
-	input := utilities.LoadInput(filename)
+// countZeroPasses counts every click that lands the dial on 0
+func countZeroPasses(commands []*Command) int {
 	position := 50
 	numZeroes := 0
 
-	for _, line := range input {
-		command := parseCommand(line)
-
+	for _, command := range commands {
 		switch command.Direction {
 		case "L":
 			for i := 0; i < command.Steps; i++ {
@@ -67,7 +119,7 @@ func executePartTwo(filename string) {
 		}
 	}
 
-	fmt.Println(numZeroes)
+	return numZeroes
 }
 
 type Command struct {
@@ -82,3 +134,14 @@ func parseCommand(command string) *Command {
 		Steps:     steps,
 	}
 }
+
+func parseCommands(lines []string) []*Command {
+	commands := make([]*Command, 0, len(lines))
+	for _, line := range lines {
+		if line == "" {
+			continue
+		}
+		commands = append(commands, parseCommand(line))
+	}
+	return commands
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/stephen-condon/advent-of-code-2025/utilities"
+)
+
+// benchParts splits each part into parsing and solving for -bench
+func benchParts(c utilities.Case) []utilities.BenchPart {
+	return []utilities.BenchPart{
+		{Name: "part one", Parse: func(lines []string) (utilities.Solver, error) {
+			ranges := parseRanges(lines[0])
+			return func() (string, error) {
+				total, err := sumInvalidIDs(context.Background(), ranges, findInvalidIDsInRange)
+				return strconv.Itoa(total), err
+			}, nil
+		}},
+		{Name: "part two", Parse: func(lines []string) (utilities.Solver, error) {
+			ranges := parseRanges(lines[0])
+			return func() (string, error) {
+				total, err := sumInvalidIDs(context.Background(), ranges, findInvalidIDsInRangePartTwo)
+				return strconv.Itoa(total), err
+			}, nil
+		}},
+	}
+}
@@ -1,7 +1,10 @@
 package main
 
 import (
+	"context"
+	"flag"
 	"fmt"
+	"os"
 	"strconv"
 	"strings"
 
@@ -31,35 +34,76 @@ import (
 */
 
 func main() {
+	bench := utilities.AddBenchFlags(flag.CommandLine)
+	profile := utilities.AddProfileFlags(flag.CommandLine)
+	selection := utilities.AddSelectionFlags(flag.CommandLine)
+	timeout := flag.Duration("timeout", 0, "give up on a part after this long and report TIMEOUT, 0 for no limit")
+	flag.Parse()
+
+	cases, err := utilities.LoadCases(".")
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	if bench.Enabled() {
+		if err := bench.Run("day2", cases, benchParts); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	// Exit non-zero if any part fails, so that run-all and scripts notice
+	failed := false
+
 	fmt.Println("Part One:")
-	executePartOne("example.txt")
-	executePartOne("input.txt")
+	for _, c := range selection.Cases(cases, 1) {
+		answer := profile.Run("day2", c, 1, func() string {
+			ctx, cancel := utilities.PartContext(*timeout)
+			defer cancel()
+			return executePartOne(ctx, c.File)
+		})
+		if answer == "" || !utilities.CheckAnswer(c, 1, answer) {
+			failed = true
+		}
+	}
 
 	fmt.Println("\nPart Two:")
-	executePartTwo("example.txt")
-	executePartTwo("input.txt")
+	for _, c := range selection.Cases(cases, 2) {
+		answer := profile.Run("day2", c, 2, func() string {
+			ctx, cancel := utilities.PartContext(*timeout)
+			defer cancel()
+			return executePartTwo(ctx, c.File)
+		})
+		if answer == "" || !utilities.CheckAnswer(c, 2, answer) {
+			failed = true
+		}
+	}
+
+	if failed {
+		os.Exit(1)
+	}
 }
 
-func executePartOne(filename string) {
+func executePartOne(ctx context.Context, filename string) string {
 	input := utilities.LoadInput(filename)
 	if len(input) == 0 {
 		fmt.Println("No input found")
-		return
+		return ""
 	}
 
 	// Parse the ranges from the first line
 	ranges := parseRanges(input[0])
 
-	totalInvalidSum := 0
-
-	for _, r := range ranges {
-		invalidIDs := findInvalidIDsInRange(r.start, r.end)
-		for _, id := range invalidIDs {
-			totalInvalidSum += id
-		}
+	totalInvalidSum, err := sumInvalidIDs(ctx, ranges, findInvalidIDsInRange)
+	if err != nil {
+		utilities.PrintPartError(filename, err)
+		return ""
 	}
 
 	fmt.Printf("%s: %d\n", filename, totalInvalidSum)
+	return strconv.Itoa(totalInvalidSum)
 }
 
 // implement a executePartTwo method, with the following changes to assumptions (do not modify any code used for part one)
@@ -82,38 +126,39 @@ func executePartOne(filename string) {
 	Adding up all the invalid IDs in this example produces 4174379265.
 */
 
-func executePartTwo(filename string) {
+func executePartTwo(ctx context.Context, filename string) string {
 	input := utilities.LoadInput(filename)
 	if len(input) == 0 {
 		fmt.Println("No input found")
-		return
+		return ""
 	}
 
 	// Parse the ranges from the first line
 	ranges := parseRanges(input[0])
 
-	totalInvalidSum := 0
-
-	for _, r := range ranges {
-		invalidIDs := findInvalidIDsInRangePartTwo(r.start, r.end)
-		for _, id := range invalidIDs {
-			totalInvalidSum += id
-		}
+	totalInvalidSum, err := sumInvalidIDs(ctx, ranges, findInvalidIDsInRangePartTwo)
+	if err != nil {
+		utilities.PrintPartError(filename, err)
+		return ""
 	}
 
 	fmt.Printf("%s: %d\n", filename, totalInvalidSum)
+	return strconv.Itoa(totalInvalidSum)
 }
 
-func findInvalidIDsInRangePartTwo(start, end int) []int {
+func findInvalidIDsInRangePartTwo(ctx context.Context, start, end int) ([]int, error) {
 	var invalidIDs []int
 
 	for id := start; id <= end; id++ {
+		if (id-start)%cancelCheckInterval == 0 && ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
 		if isInvalidIDPartTwo(id) {
 			invalidIDs = append(invalidIDs, id)
 		}
 	}
 
-	return invalidIDs
+	return invalidIDs, nil
 }
 
 // isInvalidIDPartTwo checks if a number is made of a sequence repeated at least twice
@@ -172,16 +217,38 @@ func parseRanges(line string) []Range {
 	return ranges
 }
 
-func findInvalidIDsInRange(start, end int) []int {
+// cancelCheckInterval is how many IDs are checked between looking for
+// cancellation, since ranges can be huge
+const cancelCheckInterval = 1 << 14
+
+// sumInvalidIDs adds up the IDs that find reports as invalid in each range
+func sumInvalidIDs(ctx context.Context, ranges []Range, find func(ctx context.Context, start, end int) ([]int, error)) (int, error) {
+	total := 0
+	for _, r := range ranges {
+		invalidIDs, err := find(ctx, r.start, r.end)
+		if err != nil {
+			return 0, err
+		}
+		for _, id := range invalidIDs {
+			total += id
+		}
+	}
+	return total, nil
+}
+
+func findInvalidIDsInRange(ctx context.Context, start, end int) ([]int, error) {
 	var invalidIDs []int
 
 	for id := start; id <= end; id++ {
+		if (id-start)%cancelCheckInterval == 0 && ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
 		if isInvalidID(id) {
 			invalidIDs = append(invalidIDs, id)
 		}
 	}
 
-	return invalidIDs
+	return invalidIDs, nil
 }
 
 // isInvalidID checks if a number is made of a sequence repeated twice
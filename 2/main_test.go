@@ -0,0 +1,24 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestHugeRangeTimesOut(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	// Checking every ID up to 10^15 would take days
+	ranges := []Range{{start: 1, end: 1_000_000_000_000_000}}
+	start := time.Now()
+	_, err := sumInvalidIDs(ctx, ranges, findInvalidIDsInRangePartTwo)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("error = %v, want context.DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("took %v to notice the deadline", elapsed)
+	}
+}
@@ -0,0 +1,23 @@
+package main
+
+import (
+	"strconv"
+
+	"github.com/stephen-condon/advent-of-code-2025/utilities"
+)
+
+// benchParts splits each part into parsing and solving for -bench
+func benchParts(c utilities.Case) []utilities.BenchPart {
+	return []utilities.BenchPart{
+		{Name: "part one", Parse: func(lines []string) (utilities.Solver, error) {
+			return func() (string, error) {
+				return strconv.Itoa(sumLargest(lines, findLargestTwoDigitNumber)), nil
+			}, nil
+		}},
+		{Name: "part two", Parse: func(lines []string) (utilities.Solver, error) {
+			return func() (string, error) {
+				return strconv.Itoa(sumLargest(lines, findLargestTwelveDigitNumber)), nil
+			}, nil
+		}},
+	}
+}
@@ -2,4 +2,6 @@ module day3
 
 go 1.25.0
 
-require github.com/stephen-condon/advent-of-code-2025/utilities v0.0.0-20260102034909-835d6054974b
+require github.com/stephen-condon/advent-of-code-2025/utilities v0.0.0
+
+replace github.com/stephen-condon/advent-of-code-2025/utilities => ../utilities
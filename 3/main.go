@@ -1,53 +1,95 @@
 package main
 
 import (
+	"flag"
 	"fmt"
+	"os"
+	"strconv"
 
 	"github.com/stephen-condon/advent-of-code-2025/utilities"
 )
 
 func main() {
+	bench := utilities.AddBenchFlags(flag.CommandLine)
+	profile := utilities.AddProfileFlags(flag.CommandLine)
+	selection := utilities.AddSelectionFlags(flag.CommandLine)
+	flag.Parse()
+
+	cases, err := utilities.LoadCases(".")
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	if bench.Enabled() {
+		if err := bench.Run("day3", cases, benchParts); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	// Exit non-zero if any part fails, so that run-all and scripts notice
+	failed := false
+
 	fmt.Println("Part One:")
-	executePartOne("example.txt")
-	executePartOne("input.txt")
+	for _, c := range selection.Cases(cases, 1) {
+		answer := profile.Run("day3", c, 1, func() string {
+			return executePartOne(c.File)
+		})
+		if answer == "" || !utilities.CheckAnswer(c, 1, answer) {
+			failed = true
+		}
+	}
 
 	fmt.Println("\nPart Two:")
-	executePartTwo("example.txt")
-	executePartTwo("input.txt")
+	for _, c := range selection.Cases(cases, 2) {
+		answer := profile.Run("day3", c, 2, func() string {
+			return executePartTwo(c.File)
+		})
+		if answer == "" || !utilities.CheckAnswer(c, 2, answer) {
+			failed = true
+		}
+	}
+
+	if failed {
+		os.Exit(1)
+	}
 }
 
-func executePartOne(filename string) {
+func executePartOne(filename string) string {
 	input := utilities.LoadInput(filename)
 	if len(input) == 0 {
 		fmt.Println("No input found")
-		return
+		return ""
 	}
 
-	totalSum := 0
-
-	for _, line := range input {
-		maxNumber := findLargestTwoDigitNumber(line)
-		totalSum += maxNumber
-	}
+	totalSum := sumLargest(input, findLargestTwoDigitNumber)
 
 	fmt.Printf("%s: %d\n", filename, totalSum)
+	return strconv.Itoa(totalSum)
 }
 
-func executePartTwo(filename string) {
+func executePartTwo(filename string) string {
 	input := utilities.LoadInput(filename)
 	if len(input) == 0 {
 		fmt.Println("No input found")
-		return
+		return ""
 	}
 
-	totalSum := 0
-
-	for _, line := range input {
-		maxNumber := findLargestTwelveDigitNumber(line)
-		totalSum += maxNumber
-	}
+	totalSum := sumLargest(input, findLargestTwelveDigitNumber)
 
 	fmt.Printf("%s: %d\n", filename, totalSum)
+	return strconv.Itoa(totalSum)
+}
+
+// sumLargest adds up the largest number find picks out of each bank
+func sumLargest(lines []string, find func(line string) int) int {
+	totalSum := 0
+	for _, line := range lines {
+		totalSum += find(line)
+	}
+	return totalSum
 }
 
 func findLargestTwelveDigitNumber(line string) int {
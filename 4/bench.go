@@ -0,0 +1,26 @@
+package main
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/stephen-condon/advent-of-code-2025/utilities"
+)
+
+// benchParts splits each part into parsing and solving for -bench
+func benchParts(c utilities.Case) []utilities.BenchPart {
+	return []utilities.BenchPart{
+		{Name: "part one", Parse: func(lines []string) (utilities.Solver, error) {
+			return func() (string, error) {
+				count, err := countAccessibleRolls(context.Background(), lines)
+				return strconv.Itoa(count), err
+			}, nil
+		}},
+		{Name: "part two", Parse: func(lines []string) (utilities.Solver, error) {
+			return func() (string, error) {
+				removed, err := removeAccessibleRolls(context.Background(), lines)
+				return strconv.Itoa(removed), err
+			}, nil
+		}},
+	}
+}
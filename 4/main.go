@@ -1,44 +1,101 @@
 package main
 
 import (
+	"context"
+	"flag"
 	"fmt"
+	"os"
+	"strconv"
 
 	"github.com/stephen-condon/advent-of-code-2025/utilities"
 )
 
 func main() {
+	bench := utilities.AddBenchFlags(flag.CommandLine)
+	profile := utilities.AddProfileFlags(flag.CommandLine)
+	selection := utilities.AddSelectionFlags(flag.CommandLine)
+	timeout := flag.Duration("timeout", 0, "give up on a part after this long and report TIMEOUT, 0 for no limit")
+	flag.Parse()
+
+	cases, err := utilities.LoadCases(".")
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	if bench.Enabled() {
+		if err := bench.Run("day4", cases, benchParts); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	// Exit non-zero if any part fails, so that run-all and scripts notice
+	failed := false
+
 	fmt.Println("Part One:")
-	executePartOne("example.txt")
-	executePartOne("input.txt")
+	for _, c := range selection.Cases(cases, 1) {
+		answer := profile.Run("day4", c, 1, func() string {
+			ctx, cancel := utilities.PartContext(*timeout)
+			defer cancel()
+			return executePartOne(ctx, c.File)
+		})
+		if answer == "" || !utilities.CheckAnswer(c, 1, answer) {
+			failed = true
+		}
+	}
 
 	fmt.Println("\nPart Two:")
-	executePartTwo("example.txt")
-	executePartTwo("input.txt")
+	for _, c := range selection.Cases(cases, 2) {
+		answer := profile.Run("day4", c, 2, func() string {
+			ctx, cancel := utilities.PartContext(*timeout)
+			defer cancel()
+			return executePartTwo(ctx, c.File)
+		})
+		if answer == "" || !utilities.CheckAnswer(c, 2, answer) {
+			failed = true
+		}
+	}
+
+	if failed {
+		os.Exit(1)
+	}
 }
 
-func executePartOne(filename string) {
+func executePartOne(ctx context.Context, filename string) string {
 	input := utilities.LoadInput(filename)
 	if len(input) == 0 {
 		fmt.Println("No input found")
-		return
+		return ""
 	}
 
-	accessibleCount := countAccessibleRolls(input)
+	accessibleCount, err := countAccessibleRolls(ctx, input)
+	if err != nil {
+		utilities.PrintPartError(filename, err)
+		return ""
+	}
 	fmt.Printf("%s: %d\n", filename, accessibleCount)
+	return strconv.Itoa(accessibleCount)
 }
 
-func executePartTwo(filename string) {
+func executePartTwo(ctx context.Context, filename string) string {
 	input := utilities.LoadInput(filename)
 	if len(input) == 0 {
 		fmt.Println("No input found")
-		return
+		return ""
 	}
 
-	totalRemoved := removeAccessibleRolls(input)
+	totalRemoved, err := removeAccessibleRolls(ctx, input)
+	if err != nil {
+		utilities.PrintPartError(filename, err)
+		return ""
+	}
 	fmt.Printf("%s: %d\n", filename, totalRemoved)
+	return strconv.Itoa(totalRemoved)
 }
 
-func removeAccessibleRolls(grid []string) int {
+func removeAccessibleRolls(ctx context.Context, grid []string) (int, error) {
 	// Create a mutable copy of the grid
 	mutableGrid := make([][]rune, len(grid))
 	for i, line := range grid {
@@ -48,6 +105,11 @@ func removeAccessibleRolls(grid []string) int {
 	totalRemoved := 0
 
 	for {
+		// Each round rescans the whole grid, so check between rounds
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+
 		accessiblePositions := findAccessiblePositions(mutableGrid)
 
 		if len(accessiblePositions) == 0 {
@@ -61,7 +123,7 @@ func removeAccessibleRolls(grid []string) int {
 		totalRemoved += len(accessiblePositions)
 	}
 
-	return totalRemoved
+	return totalRemoved, nil
 }
 
 func findAccessiblePositions(grid [][]rune) [][2]int {
@@ -100,10 +162,13 @@ func isAccessibleMutable(grid [][]rune, row, col int) bool {
 	return rollCount <= 3
 }
 
-func countAccessibleRolls(grid []string) int {
+func countAccessibleRolls(ctx context.Context, grid []string) (int, error) {
 	count := 0
 
 	for row := 0; row < len(grid); row++ {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
 		for col := 0; col < len(grid[row]); col++ {
 			if grid[row][col] == '@' && isAccessible(grid, row, col) {
 				count++
@@ -111,7 +176,7 @@ func countAccessibleRolls(grid []string) int {
 		}
 	}
 
-	return count
+	return count, nil
 }
 
 func isAccessible(grid []string, row, col int) bool {
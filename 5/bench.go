@@ -0,0 +1,25 @@
+package main
+
+import (
+	"strconv"
+
+	"github.com/stephen-condon/advent-of-code-2025/utilities"
+)
+
+// benchParts splits each part into parsing and solving for -bench
+func benchParts(c utilities.Case) []utilities.BenchPart {
+	return []utilities.BenchPart{
+		{Name: "part one", Parse: func(lines []string) (utilities.Solver, error) {
+			ranges, ids := parseInput(lines)
+			return func() (string, error) {
+				return strconv.Itoa(countFreshIngredients(ranges, ids)), nil
+			}, nil
+		}},
+		{Name: "part two", Parse: func(lines []string) (utilities.Solver, error) {
+			ranges, _ := parseInput(lines)
+			return func() (string, error) {
+				return strconv.Itoa(countTotalFreshIDs(ranges)), nil
+			}, nil
+		}},
+	}
+}
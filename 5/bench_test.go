@@ -0,0 +1,15 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stephen-condon/advent-of-code-2025/utilities"
+)
+
+func BenchmarkPartOne(b *testing.B) {
+	utilities.BenchmarkCase(b, "input.txt", 1, benchParts)
+}
+
+func BenchmarkPartTwo(b *testing.B) {
+	utilities.BenchmarkCase(b, "input.txt", 2, benchParts)
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/stephen-condon/advent-of-code-2025/utilities"
+)
+
+// RangeSource is one original input range as written in the file
+type RangeSource struct {
+	Line  int `json:"line"`
+	Start int `json:"start"`
+	End   int `json:"end"`
+}
+
+// Explanation lists every input range that contains an ID
+type Explanation struct {
+	ID      int           `json:"id"`
+	Fresh   bool          `json:"fresh"`
+	Sources []RangeSource `json:"sources"`
+}
+
+// MergedRange is one range produced by mergeRanges along with the input
+// ranges that were folded into it
+type MergedRange struct {
+	Start   int           `json:"start"`
+	End     int           `json:"end"`
+	Count   int           `json:"count"`
+	Sources []RangeSource `json:"sources"`
+}
+
+func sourceOf(r Range) RangeSource {
+	return RangeSource{Line: r.line, Start: r.start, End: r.end}
+}
+
+// explainID finds every original range containing id, in input order
+func explainID(id int, ranges []Range) Explanation {
+	explanation := Explanation{ID: id, Sources: []RangeSource{}}
+	for _, r := range ranges {
+		if id >= r.start && id <= r.end {
+			explanation.Sources = append(explanation.Sources, sourceOf(r))
+		}
+	}
+	explanation.Fresh = len(explanation.Sources) > 0
+	return explanation
+}
+
+// mergedRangesWithSources attributes each original range to the merged
+// range that absorbed it
+func mergedRangesWithSources(ranges []Range) []MergedRange {
+	merged := mergeRanges(ranges)
+	result := make([]MergedRange, len(merged))
+	for i, m := range merged {
+		result[i] = MergedRange{Start: m.start, End: m.end, Count: m.end - m.start + 1, Sources: []RangeSource{}}
+	}
+
+	for _, r := range ranges {
+		i := sort.Search(len(merged), func(i int) bool {
+			return merged[i].end >= r.start
+		})
+		result[i].Sources = append(result[i].Sources, sourceOf(r))
+	}
+
+	return result
+}
+
+func formatSources(sources []RangeSource) string {
+	parts := make([]string, len(sources))
+	for i, s := range sources {
+		parts[i] = fmt.Sprintf("%d-%d (line %d)", s.Start, s.End, s.Line)
+	}
+	return strings.Join(parts, ", ")
+}
+
+func writeExplanations(w io.Writer, explanations []Explanation, format string) error {
+	switch format {
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(explanations)
+	case "text":
+		for _, e := range explanations {
+			if !e.Fresh {
+				fmt.Fprintf(w, "%d: spoiled\n", e.ID)
+				continue
+			}
+			fmt.Fprintf(w, "%d: fresh via %s\n", e.ID, formatSources(e.Sources))
+		}
+		return nil
+	}
+	return fmt.Errorf("unknown format %q", format)
+}
+
+func writeMergedRanges(w io.Writer, merged []MergedRange, format string) error {
+	switch format {
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(merged)
+	case "text":
+		for _, m := range merged {
+			fmt.Fprintf(w, "%d-%d (%d IDs) from %s\n", m.Start, m.End, m.Count, formatSources(m.Sources))
+		}
+		return nil
+	}
+	return fmt.Errorf("unknown format %q", format)
+}
+
+// explain prints which input ranges make each ID in the file fresh
+func explain(args []string) error {
+	fs := flag.NewFlagSet("explain", flag.ExitOnError)
+	filename := fs.String("input", "example.txt", "inventory file to explain")
+	format := fs.String("format", "text", "output format: text or json")
+	fs.Parse(args)
+
+	ranges, ids := parseInput(utilities.LoadInput(*filename))
+	explanations := make([]Explanation, 0, len(ids))
+	for _, id := range ids {
+		explanations = append(explanations, explainID(id, ranges))
+	}
+
+	return writeExplanations(os.Stdout, explanations, *format)
+}
+
+// exportRanges prints the merged ranges used by countTotalFreshIDs
+func exportRanges(args []string) error {
+	fs := flag.NewFlagSet("ranges", flag.ExitOnError)
+	filename := fs.String("input", "example.txt", "inventory file to merge")
+	format := fs.String("format", "text", "output format: text or json")
+	fs.Parse(args)
+
+	ranges, _ := parseInput(utilities.LoadInput(*filename))
+	if len(ranges) == 0 {
+		return fmt.Errorf("no ranges found in %s", *filename)
+	}
+
+	return writeMergedRanges(os.Stdout, mergedRangesWithSources(ranges), *format)
+}
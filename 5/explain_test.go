@@ -0,0 +1,84 @@
+package main
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stephen-condon/advent-of-code-2025/utilities"
+)
+
+func exampleRanges(t *testing.T) []Range {
+	t.Helper()
+	ranges, _ := parseInput(utilities.LoadInput("example.txt"))
+	if len(ranges) == 0 {
+		t.Fatal("no ranges in example.txt")
+	}
+	return ranges
+}
+
+func TestExplainIDOnExample(t *testing.T) {
+	ranges := exampleRanges(t)
+
+	cases := map[int][]RangeSource{
+		1:  {},
+		5:  {{Line: 1, Start: 3, End: 5}},
+		8:  {},
+		11: {{Line: 2, Start: 10, End: 14}},
+		17: {{Line: 3, Start: 16, End: 20}, {Line: 4, Start: 12, End: 18}},
+		32: {},
+	}
+	for id, want := range cases {
+		got := explainID(id, ranges)
+		if !reflect.DeepEqual(got.Sources, want) {
+			t.Errorf("explainID(%d) sources = %v, want %v", id, got.Sources, want)
+		}
+		if got.Fresh != (len(want) > 0) {
+			t.Errorf("explainID(%d) fresh = %v, want %v", id, got.Fresh, len(want) > 0)
+		}
+	}
+}
+
+func TestWriteMergedRangesText(t *testing.T) {
+	var b strings.Builder
+	if err := writeMergedRanges(&b, mergedRangesWithSources(exampleRanges(t)), "text"); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "3-5 (3 IDs) from 3-5 (line 1)\n" +
+		"10-20 (11 IDs) from 10-14 (line 2), 16-20 (line 3), 12-18 (line 4)\n"
+	if b.String() != want {
+		t.Errorf("text export:\n%s\nwant:\n%s", b.String(), want)
+	}
+}
+
+func TestWriteMergedRangesJSON(t *testing.T) {
+	var b strings.Builder
+	if err := writeMergedRanges(&b, mergedRangesWithSources(exampleRanges(t)), "json"); err != nil {
+		t.Fatal(err)
+	}
+
+	var got []MergedRange
+	if err := json.Unmarshal([]byte(b.String()), &got); err != nil {
+		t.Fatalf("export isn't JSON: %v\n%s", err, b.String())
+	}
+	want := []MergedRange{
+		{Start: 3, End: 5, Count: 3, Sources: []RangeSource{{Line: 1, Start: 3, End: 5}}},
+		{Start: 10, End: 20, Count: 11, Sources: []RangeSource{
+			{Line: 2, Start: 10, End: 14},
+			{Line: 3, Start: 16, End: 20},
+			{Line: 4, Start: 12, End: 18},
+		}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("JSON export = %+v, want %+v", got, want)
+	}
+}
+
+func TestWriteMergedRangesUnknownFormat(t *testing.T) {
+	var b strings.Builder
+	if err := writeMergedRanges(&b, nil, "xml"); err == nil {
+		t.Error("expected an error for format xml")
+	}
+}
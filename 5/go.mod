@@ -2,4 +2,6 @@ module day5
 
 go 1.25.0
 
-require github.com/stephen-condon/advent-of-code-2025/utilities v0.0.0-20260103132020-e1aad91a8f5f
+require github.com/stephen-condon/advent-of-code-2025/utilities v0.0.0
+
+replace github.com/stephen-condon/advent-of-code-2025/utilities => ../utilities
@@ -1,7 +1,9 @@
 package main
 
 import (
+	"flag"
 	"fmt"
+	"os"
 	"strconv"
 	"strings"
 
@@ -9,37 +11,99 @@ import (
 )
 
 func main() {
+	// Anything other than a flag is a subcommand
+	if len(os.Args) > 1 && !strings.HasPrefix(os.Args[1], "-") {
+		var err error
+		switch os.Args[1] {
+		case "serve":
+			err = serve(os.Args[2:])
+		case "explain":
+			err = explain(os.Args[2:])
+		case "ranges":
+			err = exportRanges(os.Args[2:])
+		case "updates":
+			err = updates(os.Args[2:])
+		default:
+			err = fmt.Errorf("unknown command %q", os.Args[1])
+		}
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	bench := utilities.AddBenchFlags(flag.CommandLine)
+	profile := utilities.AddProfileFlags(flag.CommandLine)
+	selection := utilities.AddSelectionFlags(flag.CommandLine)
+	flag.Parse()
+
+	cases, err := utilities.LoadCases(".")
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	if bench.Enabled() {
+		if err := bench.Run("day5", cases, benchParts); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	// Exit non-zero if any part fails, so that run-all and scripts notice
+	failed := false
+
 	fmt.Println("Part One:")
-	executePartOne("example.txt")
-	executePartOne("input.txt")
+	for _, c := range selection.Cases(cases, 1) {
+		answer := profile.Run("day5", c, 1, func() string {
+			return executePartOne(c.File)
+		})
+		if answer == "" || !utilities.CheckAnswer(c, 1, answer) {
+			failed = true
+		}
+	}
 
 	fmt.Println("\nPart Two:")
-	executePartTwo("example.txt")
-	executePartTwo("input.txt")
+	for _, c := range selection.Cases(cases, 2) {
+		answer := profile.Run("day5", c, 2, func() string {
+			return executePartTwo(c.File)
+		})
+		if answer == "" || !utilities.CheckAnswer(c, 2, answer) {
+			failed = true
+		}
+	}
+
+	if failed {
+		os.Exit(1)
+	}
 }
 
-func executePartOne(filename string) {
+func executePartOne(filename string) string {
 	input := utilities.LoadInput(filename)
 	if len(input) == 0 {
 		fmt.Println("No input found")
-		return
+		return ""
 	}
 
 	ranges, ids := parseInput(input)
 	freshCount := countFreshIngredients(ranges, ids)
 	fmt.Printf("%s: %d\n", filename, freshCount)
+	return strconv.Itoa(freshCount)
 }
 
-func executePartTwo(filename string) {
+func executePartTwo(filename string) string {
 	input := utilities.LoadInput(filename)
 	if len(input) == 0 {
 		fmt.Println("No input found")
-		return
+		return ""
 	}
 
 	ranges, _ := parseInput(input)
 	totalFresh := countTotalFreshIDs(ranges)
 	fmt.Printf("%s: %d\n", filename, totalFresh)
+	return strconv.Itoa(totalFresh)
 }
 
 func countTotalFreshIDs(ranges []Range) int {
@@ -47,6 +111,21 @@ func countTotalFreshIDs(ranges []Range) int {
 		return 0
 	}
 
+	totalCount := 0
+	for _, r := range mergeRanges(ranges) {
+		totalCount += r.end - r.start + 1
+	}
+
+	return totalCount
+}
+
+// mergeRanges returns the ranges sorted by start, with overlapping and
+// adjacent ranges combined
+func mergeRanges(ranges []Range) []Range {
+	if len(ranges) == 0 {
+		return nil
+	}
+
 	// Sort ranges by start position
 	sortedRanges := make([]Range, len(ranges))
 	copy(sortedRanges, ranges)
@@ -60,34 +139,33 @@ func countTotalFreshIDs(ranges []Range) int {
 		}
 	}
 
-	// Merge overlapping ranges and count total IDs
-	totalCount := 0
-	currentStart := sortedRanges[0].start
-	currentEnd := sortedRanges[0].end
+	// Merge overlapping ranges
+	var merged []Range
+	current := Range{start: sortedRanges[0].start, end: sortedRanges[0].end}
 
 	for i := 1; i < len(sortedRanges); i++ {
-		if sortedRanges[i].start <= currentEnd+1 {
+		if sortedRanges[i].start <= current.end+1 {
 			// Ranges overlap or are adjacent, merge them
-			if sortedRanges[i].end > currentEnd {
-				currentEnd = sortedRanges[i].end
+			if sortedRanges[i].end > current.end {
+				current.end = sortedRanges[i].end
 			}
 		} else {
-			// No overlap, count the current range and start a new one
-			totalCount += currentEnd - currentStart + 1
-			currentStart = sortedRanges[i].start
-			currentEnd = sortedRanges[i].end
+			// No overlap, keep the current range and start a new one
+			merged = append(merged, current)
+			current = Range{start: sortedRanges[i].start, end: sortedRanges[i].end}
 		}
 	}
 
 	// Add the last range
-	totalCount += currentEnd - currentStart + 1
+	merged = append(merged, current)
 
-	return totalCount
+	return merged
 }
 
 type Range struct {
 	start int
 	end   int
+	line  int // 1-based line number in the input, 0 if not parsed from a file
 }
 
 func parseInput(lines []string) ([]Range, []int) {
@@ -95,7 +173,7 @@ func parseInput(lines []string) ([]Range, []int) {
 	var ids []int
 	parsingRanges := true
 
-	for _, line := range lines {
+	for i, line := range lines {
 		line = strings.TrimSpace(line)
 
 		if line == "" {
@@ -108,7 +186,7 @@ func parseInput(lines []string) ([]Range, []int) {
 			if len(parts) == 2 {
 				start, _ := strconv.Atoi(parts[0])
 				end, _ := strconv.Atoi(parts[1])
-				ranges = append(ranges, Range{start: start, end: end})
+				ranges = append(ranges, Range{start: start, end: end, line: i + 1})
 			}
 		} else {
 			id, _ := strconv.Atoi(line)
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/stephen-condon/advent-of-code-2025/utilities"
+)
+
+// FreshnessServer answers freshness lookups against a ranges section that
+// is loaded and merged once at startup
+type FreshnessServer struct {
+	merged     []Range
+	totalFresh int
+}
+
+type freshResponse struct {
+	ID    int  `json:"id"`
+	Fresh bool `json:"fresh"`
+}
+
+type batchRequest struct {
+	IDs []int `json:"ids"`
+}
+
+type batchResponse struct {
+	Results    []freshResponse `json:"results"`
+	FreshCount int             `json:"freshCount"`
+}
+
+type statsResponse struct {
+	MergedRanges int `json:"mergedRanges"`
+	TotalFresh   int `json:"totalFresh"`
+}
+
+func NewFreshnessServer(ranges []Range) *FreshnessServer {
+	merged := mergeRanges(ranges)
+	totalFresh := 0
+	for _, r := range merged {
+		totalFresh += r.end - r.start + 1
+	}
+	return &FreshnessServer{merged: merged, totalFresh: totalFresh}
+}
+
+// isFresh binary searches the merged ranges for the one that could hold id
+func (s *FreshnessServer) isFresh(id int) bool {
+	i := sort.Search(len(s.merged), func(i int) bool {
+		return s.merged[i].end >= id
+	})
+	return i < len(s.merged) && s.merged[i].start <= id
+}
+
+func (s *FreshnessServer) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /fresh/{id}", s.handleFresh)
+	mux.HandleFunc("POST /fresh", s.handleBatch)
+	mux.HandleFunc("GET /stats", s.handleStats)
+	return mux
+}
+
+func (s *FreshnessServer) handleFresh(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("invalid id %q", r.PathValue("id")), http.StatusBadRequest)
+		return
+	}
+
+	writeJSON(w, freshResponse{ID: id, Fresh: s.isFresh(id)})
+}
+
+func (s *FreshnessServer) handleBatch(w http.ResponseWriter, r *http.Request) {
+	var req batchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, fmt.Sprintf("invalid request body: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	resp := batchResponse{Results: make([]freshResponse, 0, len(req.IDs))}
+	for _, id := range req.IDs {
+		fresh := s.isFresh(id)
+		if fresh {
+			resp.FreshCount++
+		}
+		resp.Results = append(resp.Results, freshResponse{ID: id, Fresh: fresh})
+	}
+
+	writeJSON(w, resp)
+}
+
+func (s *FreshnessServer) handleStats(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, statsResponse{MergedRanges: len(s.merged), TotalFresh: s.totalFresh})
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// serve loads the ranges section of an input file and listens for lookups
+func serve(args []string) error {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	addr := fs.String("addr", "localhost:8080", "address to listen on")
+	filename := fs.String("input", "input.txt", "inventory file to load ranges from")
+	fs.Parse(args)
+
+	ranges, _ := parseInput(utilities.LoadInput(*filename))
+	if len(ranges) == 0 {
+		return fmt.Errorf("no ranges found in %s", *filename)
+	}
+
+	server := NewFreshnessServer(ranges)
+	fmt.Printf("Serving %d merged ranges from %s on %s\n", len(server.merged), *filename, *addr)
+	return http.ListenAndServe(*addr, server.Handler())
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stephen-condon/advent-of-code-2025/utilities"
+)
+
+func newExampleServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	ranges, _ := parseInput(utilities.LoadInput("example.txt"))
+	ts := httptest.NewServer(NewFreshnessServer(ranges).Handler())
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func TestFreshLookup(t *testing.T) {
+	ts := newExampleServer(t)
+
+	cases := map[string]bool{"1": false, "5": true, "8": false, "11": true, "17": true, "32": false}
+	for id, want := range cases {
+		resp, err := http.Get(ts.URL + "/fresh/" + id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got freshResponse
+		if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if got.Fresh != want {
+			t.Errorf("GET /fresh/%s: fresh = %v, want %v", id, got.Fresh, want)
+		}
+	}
+}
+
+func TestFreshLookupInvalidID(t *testing.T) {
+	ts := newExampleServer(t)
+
+	for _, id := range []string{"abc", "1.5", "12x", "99999999999999999999"} {
+		resp, err := http.Get(ts.URL + "/fresh/" + id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Errorf("GET /fresh/%s: status = %d, want %d", id, resp.StatusCode, http.StatusBadRequest)
+		}
+	}
+}
+
+func TestFreshBatch(t *testing.T) {
+	ts := newExampleServer(t)
+
+	resp, err := http.Post(ts.URL+"/fresh", "application/json", strings.NewReader(`{"ids":[1,5,8,11,17,32]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+
+	var got batchResponse
+	if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+		t.Fatal(err)
+	}
+	want := []freshResponse{
+		{ID: 1, Fresh: false},
+		{ID: 5, Fresh: true},
+		{ID: 8, Fresh: false},
+		{ID: 11, Fresh: true},
+		{ID: 17, Fresh: true},
+		{ID: 32, Fresh: false},
+	}
+	if !reflect.DeepEqual(got.Results, want) {
+		t.Errorf("results = %+v, want %+v", got.Results, want)
+	}
+	if got.FreshCount != 3 {
+		t.Errorf("freshCount = %d, want 3", got.FreshCount)
+	}
+}
+
+func TestFreshBatchInvalidBody(t *testing.T) {
+	ts := newExampleServer(t)
+
+	bodies := map[string]string{
+		"empty":         ``,
+		"malformed":     `{"ids":[1,5`,
+		"not an object": `[1,5]`,
+		"non-numeric":   `{"ids":["five"]}`,
+	}
+	for name, body := range bodies {
+		resp, err := http.Post(ts.URL+"/fresh", "application/json", strings.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Errorf("%s body: status = %d, want %d", name, resp.StatusCode, http.StatusBadRequest)
+		}
+	}
+}
+
+func TestStats(t *testing.T) {
+	ts := newExampleServer(t)
+
+	resp, err := http.Get(ts.URL + "/stats")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	var got statsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+		t.Fatal(err)
+	}
+	if got.MergedRanges != 2 || got.TotalFresh != 14 {
+		t.Errorf("stats = %+v, want 2 merged ranges and 14 fresh IDs", got)
+	}
+}
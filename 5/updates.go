@@ -0,0 +1,198 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/stephen-condon/advent-of-code-2025/utilities"
+)
+
+// IntervalSet keeps the fresh IDs as sorted, disjoint, non-adjacent ranges
+// so that ranges can be added and removed without re-merging everything.
+// Removal has set semantics: every ID in the removed range stops being
+// fresh, whichever added range it came from.
+type IntervalSet struct {
+	ranges []Range
+	total  int
+}
+
+func NewIntervalSet(ranges []Range) *IntervalSet {
+	merged := mergeRanges(ranges)
+	set := &IntervalSet{ranges: merged}
+	for _, r := range merged {
+		set.total += r.end - r.start + 1
+	}
+	return set
+}
+
+// Add marks every ID in start..end as fresh
+func (s *IntervalSet) Add(start, end int) {
+	if start > end {
+		return
+	}
+
+	// First range that touches or follows start, counting adjacency
+	lo := sort.Search(len(s.ranges), func(i int) bool {
+		return s.ranges[i].end >= start-1
+	})
+	// First range that starts beyond end, counting adjacency
+	hi := sort.Search(len(s.ranges), func(i int) bool {
+		return s.ranges[i].start > end+1
+	})
+
+	added := Range{start: start, end: end}
+	for _, r := range s.ranges[lo:hi] {
+		s.total -= r.end - r.start + 1
+		if r.start < added.start {
+			added.start = r.start
+		}
+		if r.end > added.end {
+			added.end = r.end
+		}
+	}
+	s.total += added.end - added.start + 1
+
+	s.ranges = append(s.ranges[:lo], append([]Range{added}, s.ranges[hi:]...)...)
+}
+
+// Remove marks every ID in start..end as no longer fresh
+func (s *IntervalSet) Remove(start, end int) {
+	if start > end {
+		return
+	}
+
+	lo := sort.Search(len(s.ranges), func(i int) bool {
+		return s.ranges[i].end >= start
+	})
+	hi := sort.Search(len(s.ranges), func(i int) bool {
+		return s.ranges[i].start > end
+	})
+
+	// Keep whatever sticks out either side of the removed range
+	var remaining []Range
+	for _, r := range s.ranges[lo:hi] {
+		s.total -= r.end - r.start + 1
+		if r.start < start {
+			remaining = append(remaining, Range{start: r.start, end: start - 1})
+		}
+		if r.end > end {
+			remaining = append(remaining, Range{start: end + 1, end: r.end})
+		}
+	}
+	for _, r := range remaining {
+		s.total += r.end - r.start + 1
+	}
+
+	s.ranges = append(s.ranges[:lo], append(remaining, s.ranges[hi:]...)...)
+}
+
+func (s *IntervalSet) Contains(id int) bool {
+	i := sort.Search(len(s.ranges), func(i int) bool {
+		return s.ranges[i].end >= id
+	})
+	return i < len(s.ranges) && s.ranges[i].start <= id
+}
+
+func (s *IntervalSet) Total() int {
+	return s.total
+}
+
+type Operation struct {
+	kind  byte // '+', '-' or '?'
+	start int
+	end   int // equal to start for '?'
+}
+
+// parseOperations reads one "+a-b", "-a-b" or "?id" operation per line
+func parseOperations(lines []string) ([]Operation, error) {
+	var ops []Operation
+
+	for i, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		op := Operation{kind: line[0]}
+		switch op.kind {
+		case '+', '-':
+			parts := strings.Split(line[1:], "-")
+			if len(parts) != 2 {
+				return nil, fmt.Errorf("line %d: expected %ca-b, got %q", i+1, op.kind, line)
+			}
+			start, err := strconv.Atoi(parts[0])
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %v", i+1, err)
+			}
+			end, err := strconv.Atoi(parts[1])
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %v", i+1, err)
+			}
+			op.start, op.end = start, end
+		case '?':
+			id, err := strconv.Atoi(line[1:])
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %v", i+1, err)
+			}
+			op.start, op.end = id, id
+		default:
+			return nil, fmt.Errorf("line %d: unknown operation %q", i+1, line)
+		}
+
+		ops = append(ops, op)
+	}
+
+	return ops, nil
+}
+
+// applyOperations runs the operations in order, reporting the fresh total
+// after each change and the answer to each query
+func applyOperations(set *IntervalSet, ops []Operation) []string {
+	var results []string
+
+	for _, op := range ops {
+		switch op.kind {
+		case '+':
+			set.Add(op.start, op.end)
+			results = append(results, fmt.Sprintf("+%d-%d: %d fresh", op.start, op.end, set.Total()))
+		case '-':
+			set.Remove(op.start, op.end)
+			results = append(results, fmt.Sprintf("-%d-%d: %d fresh", op.start, op.end, set.Total()))
+		case '?':
+			status := "spoiled"
+			if set.Contains(op.start) {
+				status = "fresh"
+			}
+			results = append(results, fmt.Sprintf("?%d: %s", op.start, status))
+		}
+	}
+
+	return results
+}
+
+// updates replays an operations file, optionally on top of the ranges
+// section of an inventory file
+func updates(args []string) error {
+	fs := flag.NewFlagSet("updates", flag.ExitOnError)
+	filename := fs.String("ops", "updates_example.txt", "file of +a-b, -a-b and ?id operations")
+	base := fs.String("base", "", "inventory file whose ranges are loaded before the operations")
+	fs.Parse(args)
+
+	var ranges []Range
+	if *base != "" {
+		ranges, _ = parseInput(utilities.LoadInput(*base))
+	}
+
+	ops, err := parseOperations(utilities.LoadInput(*filename))
+	if err != nil {
+		return fmt.Errorf("%s: %v", *filename, err)
+	}
+
+	for _, result := range applyOperations(NewIntervalSet(ranges), ops) {
+		fmt.Println(result)
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIntervalSet(t *testing.T) {
+	type op struct {
+		kind       byte // '+' or '-'
+		start, end int
+		want       []Range
+		total      int
+	}
+
+	tests := []struct {
+		name    string
+		initial []Range
+		ops     []op
+	}{
+		{
+			name: "adjacent adds merge",
+			ops: []op{
+				{'+', 3, 5, []Range{{start: 3, end: 5}}, 3},
+				{'+', 6, 8, []Range{{start: 3, end: 8}}, 6},
+				{'+', 1, 2, []Range{{start: 1, end: 8}}, 8},
+			},
+		},
+		{
+			name: "overlapping adds merge",
+			ops: []op{
+				{'+', 10, 14, []Range{{start: 10, end: 14}}, 5},
+				{'+', 20, 25, []Range{{start: 10, end: 14}, {start: 20, end: 25}}, 11},
+				{'+', 12, 21, []Range{{start: 10, end: 25}}, 16},
+				{'+', 11, 13, []Range{{start: 10, end: 25}}, 16},
+			},
+		},
+		{
+			name: "gap of one stays split",
+			ops: []op{
+				{'+', 1, 3, []Range{{start: 1, end: 3}}, 3},
+				{'+', 5, 7, []Range{{start: 1, end: 3}, {start: 5, end: 7}}, 6},
+			},
+		},
+		{
+			name:    "remove splits a range",
+			initial: []Range{{start: 1, end: 10}},
+			ops: []op{
+				{'-', 4, 6, []Range{{start: 1, end: 3}, {start: 7, end: 10}}, 7},
+				{'-', 1, 1, []Range{{start: 2, end: 3}, {start: 7, end: 10}}, 6},
+				{'-', 5, 5, []Range{{start: 2, end: 3}, {start: 7, end: 10}}, 6},
+			},
+		},
+		{
+			name:    "remove across several ranges",
+			initial: []Range{{start: 3, end: 5}, {start: 10, end: 14}, {start: 16, end: 20}},
+			ops: []op{
+				{'-', 4, 17, []Range{{start: 3, end: 3}, {start: 18, end: 20}}, 4},
+			},
+		},
+		{
+			name:    "remove everything",
+			initial: []Range{{start: 3, end: 5}, {start: 10, end: 20}},
+			ops: []op{
+				{'-', 1, 100, []Range{}, 0},
+				{'+', 7, 7, []Range{{start: 7, end: 7}}, 1},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		set := NewIntervalSet(tt.initial)
+		for _, o := range tt.ops {
+			if o.kind == '+' {
+				set.Add(o.start, o.end)
+			} else {
+				set.Remove(o.start, o.end)
+			}
+
+			got := append([]Range{}, set.ranges...)
+			if !reflect.DeepEqual(got, o.want) {
+				t.Errorf("%s: after %c%d-%d ranges = %v, want %v", tt.name, o.kind, o.start, o.end, got, o.want)
+			}
+			if set.Total() != o.total {
+				t.Errorf("%s: after %c%d-%d total = %d, want %d", tt.name, o.kind, o.start, o.end, set.Total(), o.total)
+			}
+		}
+	}
+}
+
+func TestParseOperations(t *testing.T) {
+	ops, err := parseOperations([]string{"+3-5", "", " -4-4 ", "?17"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Operation{{'+', 3, 5}, {'-', 4, 4}, {'?', 17, 17}}
+	if !reflect.DeepEqual(ops, want) {
+		t.Errorf("parseOperations = %v, want %v", ops, want)
+	}
+}
+
+func TestParseOperationsErrors(t *testing.T) {
+	tests := map[string]string{
+		"+3":    `line 1: expected +a-b, got "+3"`,
+		"-1-2-": `line 1: expected -a-b, got "-1-2-"`,
+		"+a-5":  `line 1: strconv.Atoi: parsing "a": invalid syntax`,
+		"+3-b":  `line 1: strconv.Atoi: parsing "b": invalid syntax`,
+		"?x":    `line 1: strconv.Atoi: parsing "x": invalid syntax`,
+		"*3-5":  `line 1: unknown operation "*3-5"`,
+	}
+	for line, want := range tests {
+		_, err := parseOperations([]string{line})
+		if err == nil || err.Error() != want {
+			t.Errorf("parseOperations(%q) error = %v, want %s", line, err, want)
+		}
+	}
+
+	// Line numbers count blank lines too
+	_, err := parseOperations([]string{"+1-2", "", "bad"})
+	if want := `line 3: unknown operation "bad"`; err == nil || err.Error() != want {
+		t.Errorf("error = %v, want %s", err, want)
+	}
+}
+
+func TestApplyOperationsExample(t *testing.T) {
+	ops, err := parseOperations([]string{"+3-5", "+10-14", "?5", "-4-11", "?5", "?12"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := applyOperations(NewIntervalSet(nil), ops)
+	want := []string{"+3-5: 3 fresh", "+10-14: 8 fresh", "?5: fresh", "-4-11: 4 fresh", "?5: spoiled", "?12: fresh"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("applyOperations = %v, want %v", got, want)
+	}
+}
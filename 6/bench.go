@@ -0,0 +1,31 @@
+package main
+
+import (
+	"strconv"
+
+	"github.com/stephen-condon/advent-of-code-2025/utilities"
+)
+
+// benchParts splits each part into parsing and solving for -bench
+func benchParts(c utilities.Case, opts Options) []utilities.BenchPart {
+	return []utilities.BenchPart{
+		{Name: "part one", Parse: worksheetBench(HorizontalReading, opts)},
+		{Name: "part two", Parse: worksheetBench(VerticalReading, opts)},
+	}
+}
+
+func worksheetBench(reading Reading, opts Options) func(lines []string) (utilities.Solver, error) {
+	return func(lines []string) (utilities.Solver, error) {
+		worksheet, err := parseWorksheet(lines, opts.Layout)
+		if err != nil {
+			return nil, err
+		}
+		return func() (string, error) {
+			solution, err := worksheet.Solve(reading, opts.Order)
+			if err != nil {
+				return "", err
+			}
+			return strconv.Itoa(solution.GrandTotal), nil
+		}, nil
+	}
+}
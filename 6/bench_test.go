@@ -0,0 +1,20 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stephen-condon/advent-of-code-2025/utilities"
+)
+
+// defaultBenchParts benchmarks the parts with the puzzle's own options
+func defaultBenchParts(c utilities.Case) []utilities.BenchPart {
+	return benchParts(c, Options{})
+}
+
+func BenchmarkPartOne(b *testing.B) {
+	utilities.BenchmarkCase(b, "input.txt", 1, defaultBenchParts)
+}
+
+func BenchmarkPartTwo(b *testing.B) {
+	utilities.BenchmarkCase(b, "input.txt", 2, defaultBenchParts)
+}
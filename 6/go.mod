@@ -2,4 +2,6 @@ module day6
 
 go 1.25.0
 
-require github.com/stephen-condon/advent-of-code-2025/utilities v0.0.0-20260105020832-609976643ab0
+require github.com/stephen-condon/advent-of-code-2025/utilities v0.0.0
+
+replace github.com/stephen-condon/advent-of-code-2025/utilities => ../utilities
@@ -1,72 +1,171 @@
 package main
 
 import (
+	"encoding/json"
+	"flag"
 	"fmt"
+	"io"
+	"os"
 	"strconv"
-	"strings"
 
 	"github.com/stephen-condon/advent-of-code-2025/utilities"
 )
 
+// Options controls how worksheets are evaluated and reported
+type Options struct {
+	Order   EvalOrder
+	Layout  Layout
+	Verbose bool
+}
+
 func main() {
+	orderFlag := flag.String("order", "ltr", "apply each operator left-to-right (ltr) or right-to-left (rtl)")
+	verbose := flag.Bool("verbose", false, "print the worked solution of every problem")
+	jsonOutput := flag.Bool("json", false, "print the worked solutions of both parts as JSON")
+	columns := flag.String("columns", "rtl", "read a problem's columns right-to-left (rtl) or left-to-right (ltr)")
+	digits := flag.String("digits", "ttb", "read a column's digits top-to-bottom (ttb) or bottom-to-top (btt)")
+	operators := flag.String("operators", "bottom", "operator row position: bottom or top")
+	bench := utilities.AddBenchFlags(flag.CommandLine)
+	profile := utilities.AddProfileFlags(flag.CommandLine)
+	selection := utilities.AddSelectionFlags(flag.CommandLine)
+	flag.Parse()
+
+	order, err := parseEvalOrder(*orderFlag)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	layout, err := parseLayout(*columns, *digits, *operators)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	opts := Options{Order: order, Layout: layout, Verbose: *verbose}
+
+	cases, err := utilities.LoadCases(".")
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	if bench.Enabled() {
+		if err := bench.Run("day6", cases, func(c utilities.Case) []utilities.BenchPart {
+			return benchParts(c, opts)
+		}); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if *jsonOutput {
+		filenames := make([]string, len(cases))
+		for k, c := range cases {
+			filenames[k] = c.File
+		}
+		if err := writeJSONSolutions(os.Stdout, filenames, opts); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	// The expected answers are for the puzzle's own order and layout
+	check := order == LeftToRight && layout == Layout{}
+
+	// Exit non-zero if any part fails, so that run-all and scripts notice
+	failed := false
+
 	fmt.Println("Part One:")
-	executePartOne("example.txt")
-	executePartOne("input.txt")
+	for _, c := range selection.Cases(cases, 1) {
+		answer := profile.Run("day6", c, 1, func() string {
+			return executePartOne(c.File, opts)
+		})
+		if answer == "" || check && !utilities.CheckAnswer(c, 1, answer) {
+			failed = true
+		}
+	}
 
 	fmt.Println("\nPart Two:")
-	executePartTwo("example.txt")
-	executePartTwo("input.txt")
+	for _, c := range selection.Cases(cases, 2) {
+		answer := profile.Run("day6", c, 2, func() string {
+			return executePartTwo(c.File, opts)
+		})
+		if answer == "" || check && !utilities.CheckAnswer(c, 2, answer) {
+			failed = true
+		}
+	}
+
+	if failed {
+		os.Exit(1)
+	}
 }
 
-func executePartOne(filename string) {
-	input := utilities.LoadInput(filename)
-	if len(input) == 0 {
-		fmt.Println("No input found")
-		return
+func executePartOne(filename string, opts Options) string {
+	return printSolution(filename, HorizontalReading, opts)
+}
+
+func executePartTwo(filename string, opts Options) string {
+	return printSolution(filename, VerticalReading, opts)
+}
+
+// printSolution prints a file's grand total and returns it, or "" if the
+// file couldn't be solved
+func printSolution(filename string, reading Reading, opts Options) string {
+	solution, err := solveFile(filename, reading, opts)
+	if err != nil {
+		fmt.Println(err)
+		return ""
 	}
 
-	grandTotal := calculateGrandTotal(input)
-	fmt.Printf("%s: %d\n", filename, grandTotal)
+	for _, warning := range solution.Warnings {
+		fmt.Printf("  %s: warning: %s\n", filename, warning)
+	}
+	if opts.Verbose {
+		writeSteps(os.Stdout, solution)
+	}
+	fmt.Printf("%s: %d\n", filename, solution.GrandTotal)
+	return strconv.Itoa(solution.GrandTotal)
 }
 
-func executePartTwo(filename string) {
+// solveFile parses a worksheet file and evaluates it in one reading
+func solveFile(filename string, reading Reading, opts Options) (*Solution, error) {
 	input := utilities.LoadInput(filename)
 	if len(input) == 0 {
-		fmt.Println("No input found")
-		return
+		return nil, fmt.Errorf("%s: no input found", filename)
 	}
 
-	operationRow := input[len(input)-1]
-	boundaries := findProblemBoundaries(operationRow)
+	worksheet, err := parseWorksheet(input, opts.Layout)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", filename, err)
+	}
 
-	grandTotal := 0
+	solution, err := worksheet.Solve(reading, opts.Order)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", filename, err)
+	}
+	solution.File = filename
 
-	for i := len(boundaries) - 1; i >= 0; i-- {
-		startCol := boundaries[i]
-		var endCol int
-		if i < len(boundaries)-1 {
-			endCol = boundaries[i+1]
-		} else {
-			endCol = len(operationRow)
-		}
+	return solution, nil
+}
 
-		var problemLines []string
-		for j := 0; j < len(input)-1; j++ { // Exclude operation row
-			if startCol < len(input[j]) {
-				line := input[j][startCol:min(endCol, len(input[j]))]
-				line = strings.TrimRight(line, " ")
-				if line != "" {
-					problemLines = append(problemLines, line)
-				}
+// writeJSONSolutions solves every file in both readings so the two can be
+// diffed problem by problem
+func writeJSONSolutions(w io.Writer, filenames []string, opts Options) error {
+	var solutions []*Solution
+	for _, reading := range []Reading{HorizontalReading, VerticalReading} {
+		for _, filename := range filenames {
+			solution, err := solveFile(filename, reading, opts)
+			if err != nil {
+				return err
 			}
+			solutions = append(solutions, solution)
 		}
-
-		operation := string(operationRow[startCol])
-		result := solveSingleProblemVertical(problemLines, operation)
-		grandTotal += result
 	}
 
-	fmt.Printf("%s: %d\n", filename, grandTotal)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(solutions)
 }
 
 func min(a, b int) int {
@@ -94,96 +193,130 @@ func findProblemBoundaries(operationRow string) []int {
 	return boundaries
 }
 
-func solveSingleProblemVertical(problemLines []string, operation string) int {
-	maxLen := 0
-	for _, line := range problemLines {
-		if len(line) > maxLen {
-			maxLen = len(line)
-		}
-	}
-
-	var numbers []int
+func parseLayout(columns, digits, operators string) (Layout, error) {
+	var layout Layout
 
-	for col := maxLen - 1; col >= 0; col-- {
-		numStr := ""
+	switch columns {
+	case "rtl":
+	case "ltr":
+		layout.ColumnsLeftToRight = true
+	default:
+		return layout, fmt.Errorf("unknown column order %q, expected rtl or ltr", columns)
+	}
 
-		for _, line := range problemLines {
-			if col < len(line) && line[col] != ' ' {
-				numStr += string(line[col])
-			}
-		}
+	switch digits {
+	case "ttb":
+	case "btt":
+		layout.DigitsBottomToTop = true
+	default:
+		return layout, fmt.Errorf("unknown digit order %q, expected ttb or btt", digits)
+	}
 
-		if numStr != "" {
-			num, _ := strconv.Atoi(numStr)
-			numbers = append(numbers, num)
-		}
+	switch operators {
+	case "bottom":
+	case "top":
+		layout.OperatorsOnTop = true
+	default:
+		return layout, fmt.Errorf("unknown operator row position %q, expected bottom or top", operators)
 	}
 
-	return evaluateProblem(numbers, operation)
+	return layout, nil
 }
 
-func calculateGrandTotal(lines []string) int {
-	if len(lines) == 0 {
-		return 0
-	}
+// EvalOrder controls how an operator is folded over a problem's numbers
+type EvalOrder int
 
-	var tokenLines [][]string
-	for _, line := range lines {
-		tokens := strings.Fields(line)
-		tokenLines = append(tokenLines, tokens)
-	}
+const (
+	LeftToRight EvalOrder = iota // ((a op b) op c)
+	RightToLeft                  // (a op (b op c))
+)
 
-	if len(tokenLines) == 0 || len(tokenLines[0]) == 0 {
-		return 0
+func (o EvalOrder) String() string {
+	if o == RightToLeft {
+		return "rtl"
 	}
+	return "ltr"
+}
 
-	numProblems := len(tokenLines[0])
-	grandTotal := 0
-
-	for col := 0; col < numProblems; col++ {
-		var numbers []int
-		var operation string
+func parseEvalOrder(s string) (EvalOrder, error) {
+	switch s {
+	case "ltr":
+		return LeftToRight, nil
+	case "rtl":
+		return RightToLeft, nil
+	}
+	return LeftToRight, fmt.Errorf("unknown evaluation order %q, expected ltr or rtl", s)
+}
 
-		for row := 0; row < len(tokenLines); row++ {
-			if col >= len(tokenLines[row]) {
-				continue
-			}
+func evaluateProblem(numbers []int, operation string, order EvalOrder) (int, error) {
+	apply, ok := operators[operation]
+	if !ok {
+		return 0, fmt.Errorf("unknown operator %q", operation)
+	}
 
-			token := tokenLines[row][col]
+	if len(numbers) == 0 {
+		return 0, nil
+	}
 
-			if token == "+" || token == "*" {
-				operation = token
-			} else {
-				num, err := strconv.Atoi(token)
-				if err == nil {
-					numbers = append(numbers, num)
-				}
+	if order == RightToLeft {
+		result := numbers[len(numbers)-1]
+		for i := len(numbers) - 2; i >= 0; i-- {
+			var err error
+			result, err = apply(numbers[i], result)
+			if err != nil {
+				return 0, err
 			}
 		}
-
-		if len(numbers) > 0 && operation != "" {
-			result := evaluateProblem(numbers, operation)
-			grandTotal += result
-		}
-	}
-
-	return grandTotal
-}
-
-func evaluateProblem(numbers []int, operation string) int {
-	if len(numbers) == 0 {
-		return 0
+		return result, nil
 	}
 
 	result := numbers[0]
-
 	for i := 1; i < len(numbers); i++ {
-		if operation == "+" {
-			result += numbers[i]
-		} else if operation == "*" {
-			result *= numbers[i]
+		var err error
+		result, err = apply(result, numbers[i])
+		if err != nil {
+			return 0, err
 		}
 	}
 
-	return result
+	return result, nil
+}
+
+var operators = map[string]func(a, b int) (int, error){
+	"+": func(a, b int) (int, error) { return a + b, nil },
+	"-": func(a, b int) (int, error) { return a - b, nil },
+	"*": func(a, b int) (int, error) { return a * b, nil },
+	"/": func(a, b int) (int, error) {
+		if b == 0 {
+			return 0, fmt.Errorf("division by zero")
+		}
+		return a / b, nil
+	},
+	"%": func(a, b int) (int, error) {
+		if b == 0 {
+			return 0, fmt.Errorf("modulo by zero")
+		}
+		return a % b, nil
+	},
+	"^": func(a, b int) (int, error) {
+		if b < 0 {
+			return 0, fmt.Errorf("negative exponent %d", b)
+		}
+		// Exponentiation by squaring
+		result := 1
+		for ; b > 0; b >>= 1 {
+			if b&1 == 1 {
+				result *= a
+			}
+			a *= a
+		}
+		return result, nil
+	},
+	"min": func(a, b int) (int, error) { return min(a, b), nil },
+	"max": func(a, b int) (int, error) {
+		if a > b {
+			return a, nil
+		}
+		return b, nil
+	},
 }
@@ -0,0 +1,89 @@
+package main
+
+import "testing"
+
+func TestEvaluateProblem(t *testing.T) {
+	tests := []struct {
+		op      string
+		numbers []int
+		ltr     int
+		rtl     int
+	}{
+		{"+", []int{4, 431, 623}, 1058, 1058},
+		{"*", []int{123, 45, 6}, 33210, 33210},
+		{"-", []int{10, 3, 2}, 5, 9},
+		{"/", []int{100, 10, 2}, 5, 20},
+		{"%", []int{17, 5, 3}, 2, 1},
+		{"^", []int{2, 3, 2}, 64, 512},
+		{"^", []int{7, 0}, 1, 1},
+		{"min", []int{5, 2, 9}, 2, 2},
+		{"max", []int{5, 2, 9}, 9, 9},
+		{"-", []int{42}, 42, 42},
+		{"-", nil, 0, 0},
+	}
+
+	for _, tt := range tests {
+		for _, order := range []EvalOrder{LeftToRight, RightToLeft} {
+			want := tt.ltr
+			if order == RightToLeft {
+				want = tt.rtl
+			}
+			got, err := evaluateProblem(tt.numbers, tt.op, order)
+			if err != nil {
+				t.Errorf("%v %s %s: %v", tt.numbers, tt.op, order, err)
+				continue
+			}
+			if got != want {
+				t.Errorf("%v %s %s = %d, want %d", tt.numbers, tt.op, order, got, want)
+			}
+		}
+	}
+}
+
+func TestEvaluateProblemErrors(t *testing.T) {
+	tests := []struct {
+		op      string
+		numbers []int
+		order   EvalOrder
+		want    string // "" if this order succeeds
+	}{
+		{"/", []int{1, 0}, LeftToRight, "division by zero"},
+		{"/", []int{1, 0}, RightToLeft, "division by zero"},
+		// 4 / 1 / 2 is fine, but 4 / (1 / 2) divides by zero
+		{"/", []int{4, 1, 2}, LeftToRight, ""},
+		{"/", []int{4, 1, 2}, RightToLeft, "division by zero"},
+		{"%", []int{5, 0}, LeftToRight, "modulo by zero"},
+		{"%", []int{5, 3, 3}, RightToLeft, "modulo by zero"},
+		{"^", []int{2, -1}, LeftToRight, "negative exponent -1"},
+		{"^", []int{2, 1, -1}, RightToLeft, "negative exponent -1"},
+		{"&", []int{1, 2}, LeftToRight, `unknown operator "&"`},
+		{"&", nil, RightToLeft, `unknown operator "&"`},
+	}
+
+	for _, tt := range tests {
+		_, err := evaluateProblem(tt.numbers, tt.op, tt.order)
+		switch {
+		case tt.want == "" && err != nil:
+			t.Errorf("%v %s %s: unexpected error %v", tt.numbers, tt.op, tt.order, err)
+		case tt.want != "" && (err == nil || err.Error() != tt.want):
+			t.Errorf("%v %s %s: error = %v, want %s", tt.numbers, tt.op, tt.order, err, tt.want)
+		}
+	}
+}
+
+func TestUnknownOperatorNamesColumn(t *testing.T) {
+	worksheet, err := parseWorksheet([]string{
+		"12 3  4",
+		"5  67 8",
+		"+  &  *",
+	}, Layout{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = worksheet.Solve(HorizontalReading, LeftToRight)
+	want := `column 2 (characters 4-6): unknown operator "&"`
+	if err == nil || err.Error() != want {
+		t.Errorf("error = %v, want %s", err, want)
+	}
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Reading selects which of a problem's number sequences is evaluated
+type Reading string
+
+const (
+	HorizontalReading Reading = "horizontal" // part one
+	VerticalReading   Reading = "vertical"   // part two
+)
+
+// Step is the worked solution of a single problem
+type Step struct {
+	Problem      int    `json:"problem"`
+	StartCol     int    `json:"startCol"`
+	EndCol       int    `json:"endCol"`
+	Numbers      []int  `json:"numbers"`
+	Operator     string `json:"operator"`
+	Expression   string `json:"expression"`
+	Result       int    `json:"result"`
+	RunningTotal int    `json:"runningTotal"`
+}
+
+// Solution is every step of one reading of one worksheet
+type Solution struct {
+	File       string   `json:"file"`
+	Reading    Reading  `json:"reading"`
+	Order      string   `json:"order"`
+	Warnings   []string `json:"warnings,omitempty"`
+	Steps      []Step   `json:"steps"`
+	GrandTotal int      `json:"grandTotal"`
+}
+
+func (p Problem) numbers(reading Reading) []int {
+	if reading == VerticalReading {
+		return p.Vertical
+	}
+	return p.Horizontal
+}
+
+// Solve evaluates every problem in the chosen reading, keeping the working
+func (w *Worksheet) Solve(reading Reading, order EvalOrder) (*Solution, error) {
+	solution := &Solution{Reading: reading, Order: order.String(), Warnings: w.Warnings, Steps: []Step{}}
+
+	for _, p := range w.Problems {
+		numbers := p.numbers(reading)
+		result, err := p.evaluate(numbers, order)
+		if err != nil {
+			return nil, err
+		}
+
+		// Report the problem's own columns, leaving out the separator
+		endCol := p.EndCol
+		if p.Index < len(w.Problems) {
+			endCol--
+		}
+
+		solution.GrandTotal += result
+		solution.Steps = append(solution.Steps, Step{
+			Problem:      p.Index,
+			StartCol:     p.StartCol + 1,
+			EndCol:       endCol,
+			Numbers:      numbers,
+			Operator:     p.Operator,
+			Expression:   formatExpression(numbers, p.Operator, order),
+			Result:       result,
+			RunningTotal: solution.GrandTotal,
+		})
+	}
+
+	return solution, nil
+}
+
+// formatExpression writes the problem the way it is evaluated, e.g.
+// "4 + 431 + 623", bracketing right-to-left folds of more than two numbers
+func formatExpression(numbers []int, operator string, order EvalOrder) string {
+	parts := make([]string, len(numbers))
+	for i, n := range numbers {
+		parts[i] = strconv.Itoa(n)
+	}
+
+	if operator == "min" || operator == "max" {
+		return fmt.Sprintf("%s(%s)", operator, strings.Join(parts, ", "))
+	}
+
+	if order == RightToLeft && len(parts) > 2 {
+		expression := parts[len(parts)-1]
+		for i := len(parts) - 2; i >= 0; i-- {
+			if i == 0 {
+				expression = fmt.Sprintf("%s %s %s", parts[i], operator, expression)
+			} else {
+				expression = fmt.Sprintf("(%s %s %s)", parts[i], operator, expression)
+			}
+		}
+		return expression
+	}
+
+	return strings.Join(parts, " "+operator+" ")
+}
+
+// writeSteps prints the worked solution one problem per line
+func writeSteps(w io.Writer, solution *Solution) {
+	for _, step := range solution.Steps {
+		fmt.Fprintf(w, "  problem %d (columns %d-%d): %s = %d, running total %d\n",
+			step.Problem, step.StartCol, step.EndCol, step.Expression, step.Result, step.RunningTotal)
+	}
+}
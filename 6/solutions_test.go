@@ -0,0 +1,124 @@
+package main
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestFormatExpression(t *testing.T) {
+	tests := []struct {
+		numbers  []int
+		operator string
+		order    EvalOrder
+		want     string
+	}{
+		{[]int{4, 431, 623}, "+", LeftToRight, "4 + 431 + 623"},
+		{[]int{4, 431, 623}, "+", RightToLeft, "4 + (431 + 623)"},
+		{[]int{2, 3, 2, 1}, "^", RightToLeft, "2 ^ (3 ^ (2 ^ 1))"},
+		{[]int{10, 3}, "-", RightToLeft, "10 - 3"},
+		{[]int{7}, "*", LeftToRight, "7"},
+		{[]int{5, 2, 9}, "min", RightToLeft, "min(5, 2, 9)"},
+		{[]int{5, 2, 9}, "max", LeftToRight, "max(5, 2, 9)"},
+	}
+
+	for _, tt := range tests {
+		if got := formatExpression(tt.numbers, tt.operator, tt.order); got != tt.want {
+			t.Errorf("formatExpression(%v, %q, %s) = %q, want %q", tt.numbers, tt.operator, tt.order, got, tt.want)
+		}
+	}
+}
+
+func TestWriteStepsExample(t *testing.T) {
+	tests := map[Reading]string{
+		HorizontalReading: "" +
+			"  problem 1 (columns 1-3): 123 * 45 * 6 = 33210, running total 33210\n" +
+			"  problem 2 (columns 5-7): 328 + 64 + 98 = 490, running total 33700\n" +
+			"  problem 3 (columns 9-11): 51 * 387 * 215 = 4243455, running total 4277155\n" +
+			"  problem 4 (columns 13-15): 64 + 23 + 314 = 401, running total 4277556\n",
+		VerticalReading: "" +
+			"  problem 1 (columns 1-3): 356 * 24 * 1 = 8544, running total 8544\n" +
+			"  problem 2 (columns 5-7): 8 + 248 + 369 = 625, running total 9169\n" +
+			"  problem 3 (columns 9-11): 175 * 581 * 32 = 3253600, running total 3262769\n" +
+			"  problem 4 (columns 13-15): 4 + 431 + 623 = 1058, running total 3263827\n",
+	}
+
+	for reading, want := range tests {
+		solution, err := solveFile("example.txt", reading, Options{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var b strings.Builder
+		writeSteps(&b, solution)
+		if b.String() != want {
+			t.Errorf("%s steps:\n%s\nwant:\n%s", reading, b.String(), want)
+		}
+	}
+}
+
+func sortedKeys(m map[string]json.RawMessage) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func TestWriteJSONSolutionsShape(t *testing.T) {
+	var b strings.Builder
+	if err := writeJSONSolutions(&b, []string{"example.txt"}, Options{}); err != nil {
+		t.Fatal(err)
+	}
+
+	var raw []map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(b.String()), &raw); err != nil {
+		t.Fatalf("output isn't a JSON array of objects: %v", err)
+	}
+	if len(raw) != 2 {
+		t.Fatalf("got %d solutions, want one per reading", len(raw))
+	}
+
+	solutionKeys := []string{"file", "grandTotal", "order", "reading", "steps"}
+	stepKeys := []string{"endCol", "expression", "numbers", "operator", "problem", "result", "runningTotal", "startCol"}
+	for k, solution := range raw {
+		if got := sortedKeys(solution); !reflect.DeepEqual(got, solutionKeys) {
+			t.Errorf("solution %d keys = %v, want %v", k, got, solutionKeys)
+		}
+		var steps []map[string]json.RawMessage
+		if err := json.Unmarshal(solution["steps"], &steps); err != nil {
+			t.Fatal(err)
+		}
+		for s, step := range steps {
+			if got := sortedKeys(step); !reflect.DeepEqual(got, stepKeys) {
+				t.Errorf("solution %d step %d keys = %v, want %v", k, s, got, stepKeys)
+			}
+		}
+	}
+
+	var solutions []Solution
+	if err := json.Unmarshal([]byte(b.String()), &solutions); err != nil {
+		t.Fatal(err)
+	}
+	wantTotals := map[Reading]int{HorizontalReading: 4277556, VerticalReading: 3263827}
+	for k, reading := range []Reading{HorizontalReading, VerticalReading} {
+		solution := solutions[k]
+		if solution.File != "example.txt" || solution.Reading != reading || solution.Order != "ltr" {
+			t.Errorf("solution %d is %s %s %s, want example.txt %s ltr", k, solution.File, solution.Reading, solution.Order, reading)
+		}
+		if solution.GrandTotal != wantTotals[reading] {
+			t.Errorf("%s grand total = %d, want %d", reading, solution.GrandTotal, wantTotals[reading])
+		}
+	}
+
+	want := Step{
+		Problem: 4, StartCol: 13, EndCol: 15,
+		Numbers: []int{4, 431, 623}, Operator: "+", Expression: "4 + 431 + 623",
+		Result: 1058, RunningTotal: 3263827,
+	}
+	if got := solutions[1].Steps[3]; !reflect.DeepEqual(got, want) {
+		t.Errorf("last vertical step = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,192 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Problem is one column of the worksheet, with both readings of its numbers
+type Problem struct {
+	Index      int      // 1-based position from the left
+	StartCol   int      // first character column of the problem
+	EndCol     int      // one past the last character column, including the separator
+	Cells      []string // raw text of the problem's span in each number row
+	Operator   string
+	Horizontal []int // one number per row, read left to right (part one)
+	Vertical   []int // one number per character column, read as the Layout says (part two)
+}
+
+// Layout describes how a worksheet is written down. The zero value is the
+// cephalopod layout: columns read right to left, digits top to bottom, and
+// the operators on the last row.
+type Layout struct {
+	ColumnsLeftToRight bool // read a problem's columns from its left edge
+	DigitsBottomToTop  bool // the most significant digit is at the bottom
+	OperatorsOnTop     bool // the operator row is the first row
+}
+
+// Worksheet is the parsed form of a puzzle input shared by both parts
+type Worksheet struct {
+	Problems []Problem
+	Warnings []string
+}
+
+// parseWorksheet splits the worksheet into problems using the operator row.
+// Each problem starts at its operator and ends where the next one begins.
+func parseWorksheet(lines []string, layout Layout) (*Worksheet, error) {
+	// Drop blank lines left behind by a trailing newline
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	if len(lines) < 2 {
+		return nil, fmt.Errorf("worksheet needs at least one number row and an operator row")
+	}
+
+	for i, line := range lines {
+		if strings.Contains(line, "\t") {
+			return nil, fmt.Errorf("line %d: tab characters make column alignment ambiguous", i+1)
+		}
+	}
+
+	worksheet := &Worksheet{}
+	rows := lines[:len(lines)-1]
+	operationRow := lines[len(lines)-1]
+	opLine := len(lines)
+	firstRowLine := 1
+	if layout.OperatorsOnTop {
+		rows = lines[1:]
+		operationRow = lines[0]
+		opLine = 1
+		firstRowLine = 2
+	}
+
+	rowWidth := 0
+	for _, row := range rows {
+		rowWidth = max(rowWidth, len(row))
+	}
+
+	// Padding the operator row out to the grid width is normal; padding
+	// that doesn't line up with the number rows points at a mangled file
+	if trimmed := strings.TrimRight(operationRow, " "); trimmed != operationRow {
+		if len(operationRow) != rowWidth {
+			worksheet.Warnings = append(worksheet.Warnings,
+				fmt.Sprintf("line %d: operator row has trailing spaces out to column %d but the number rows end at column %d, padding ignored",
+					opLine, len(operationRow), rowWidth))
+		}
+		operationRow = trimmed
+	}
+
+	boundaries := findProblemBoundaries(operationRow)
+	if len(boundaries) == 0 {
+		return nil, fmt.Errorf("line %d: operator row has no operators", opLine)
+	}
+
+	width := max(rowWidth, len(operationRow))
+
+	// Anything left of the first operator can't belong to a problem
+	for r, row := range rows {
+		if before := strings.TrimSpace(row[:min(boundaries[0], len(row))]); before != "" {
+			return nil, fmt.Errorf("line %d: %q is left of the first operator at column %d", r+firstRowLine, before, boundaries[0]+1)
+		}
+	}
+
+	for i, startCol := range boundaries {
+		endCol := width
+		if i < len(boundaries)-1 {
+			endCol = boundaries[i+1]
+		}
+
+		problem := Problem{
+			Index:    i + 1,
+			StartCol: startCol,
+			EndCol:   endCol,
+			Operator: strings.TrimSpace(operationRow[startCol:min(endCol, len(operationRow))]),
+		}
+
+		for r, row := range rows {
+			// The column before the next operator separates the problems
+			if i < len(boundaries)-1 && endCol-1 < len(row) && row[endCol-1] != ' ' {
+				return nil, fmt.Errorf("line %d: %q at column %d runs into problem %d, expected a blank separator column",
+					r+firstRowLine, row[endCol-1], endCol, i+2)
+			}
+
+			cell := ""
+			if startCol < len(row) {
+				cell = row[startCol:min(endCol, len(row))]
+			}
+			problem.Cells = append(problem.Cells, cell)
+
+			fields := strings.Fields(cell)
+			if len(fields) > 1 {
+				return nil, fmt.Errorf("line %d: problem %d has more than one number in %q", r+firstRowLine, i+1, cell)
+			}
+			if len(fields) == 1 {
+				num, err := strconv.Atoi(fields[0])
+				if err != nil {
+					return nil, fmt.Errorf("line %d: problem %d: %q is not a number", r+firstRowLine, i+1, fields[0])
+				}
+				problem.Horizontal = append(problem.Horizontal, num)
+			}
+		}
+
+		vertical, err := readVerticalNumbers(problem.Cells, layout)
+		if err != nil {
+			return nil, fmt.Errorf("problem %d: %v", i+1, err)
+		}
+		problem.Vertical = vertical
+
+		worksheet.Problems = append(worksheet.Problems, problem)
+	}
+
+	return worksheet, nil
+}
+
+// readVerticalNumbers reads each character column of the cells as a number,
+// visiting columns and digits in the order the layout gives
+func readVerticalNumbers(cells []string, layout Layout) ([]int, error) {
+	maxLen := 0
+	for _, cell := range cells {
+		maxLen = max(maxLen, len(cell))
+	}
+
+	var numbers []int
+
+	for i := 0; i < maxLen; i++ {
+		col := maxLen - 1 - i
+		if layout.ColumnsLeftToRight {
+			col = i
+		}
+
+		numStr := ""
+
+		for j := range cells {
+			cell := cells[j]
+			if layout.DigitsBottomToTop {
+				cell = cells[len(cells)-1-j]
+			}
+			if col < len(cell) && cell[col] != ' ' {
+				numStr += string(cell[col])
+			}
+		}
+
+		if numStr != "" {
+			num, err := strconv.Atoi(numStr)
+			if err != nil {
+				return nil, fmt.Errorf("%q is not a number", numStr)
+			}
+			numbers = append(numbers, num)
+		}
+	}
+
+	return numbers, nil
+}
+
+func (p Problem) evaluate(numbers []int, order EvalOrder) (int, error) {
+	result, err := evaluateProblem(numbers, p.Operator, order)
+	if err != nil {
+		return 0, fmt.Errorf("column %d (characters %d-%d): %v", p.Index, p.StartCol+1, p.EndCol, err)
+	}
+	return result, nil
+}
@@ -0,0 +1,246 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stephen-condon/advent-of-code-2025/utilities"
+)
+
+func TestParseWorksheetExample(t *testing.T) {
+	worksheet, err := parseWorksheet(utilities.LoadInput("example.txt"), Layout{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []Problem{
+		{
+			Index: 1, StartCol: 0, EndCol: 4, Operator: "*",
+			Cells:      []string{"123 ", " 45 ", "  6 "},
+			Horizontal: []int{123, 45, 6},
+			Vertical:   []int{356, 24, 1},
+		},
+		{
+			Index: 2, StartCol: 4, EndCol: 8, Operator: "+",
+			Cells:      []string{"328 ", "64  ", "98  "},
+			Horizontal: []int{328, 64, 98},
+			Vertical:   []int{8, 248, 369},
+		},
+		{
+			Index: 3, StartCol: 8, EndCol: 12, Operator: "*",
+			Cells:      []string{" 51 ", "387 ", "215 "},
+			Horizontal: []int{51, 387, 215},
+			Vertical:   []int{175, 581, 32},
+		},
+		{
+			Index: 4, StartCol: 12, EndCol: 15, Operator: "+",
+			Cells:      []string{"64 ", "23 ", "314"},
+			Horizontal: []int{64, 23, 314},
+			Vertical:   []int{4, 431, 623},
+		},
+	}
+
+	if len(worksheet.Problems) != len(want) {
+		t.Fatalf("got %d problems, want %d", len(worksheet.Problems), len(want))
+	}
+	for i, p := range worksheet.Problems {
+		if !reflect.DeepEqual(p, want[i]) {
+			t.Errorf("problem %d = %+v, want %+v", i+1, p, want[i])
+		}
+	}
+	if len(worksheet.Warnings) != 0 {
+		t.Errorf("unexpected warnings %v", worksheet.Warnings)
+	}
+}
+
+func TestParseWorksheetShortRows(t *testing.T) {
+	// Rows that stop early leave the rest of their cells empty
+	worksheet, err := parseWorksheet([]string{
+		"1  23",
+		"45",
+		"+  *",
+	}, Layout{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	second := worksheet.Problems[1]
+	if !reflect.DeepEqual(second.Cells, []string{"23", ""}) {
+		t.Errorf("cells = %q, want [\"23\" \"\"]", second.Cells)
+	}
+	if !reflect.DeepEqual(second.Horizontal, []int{23}) {
+		t.Errorf("horizontal = %v, want [23]", second.Horizontal)
+	}
+	if !reflect.DeepEqual(second.Vertical, []int{3, 2}) {
+		t.Errorf("vertical = %v, want [3 2]", second.Vertical)
+	}
+}
+
+func TestParseWorksheetTrailingWhitespace(t *testing.T) {
+	rows := []string{"12 34", "4  5"}
+
+	// Padding out to the grid width is normal
+	worksheet, err := parseWorksheet(append(rows, "+  * "), Layout{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(worksheet.Warnings) != 0 {
+		t.Errorf("unexpected warnings %v", worksheet.Warnings)
+	}
+
+	// Padding past it is reported and ignored
+	worksheet, err = parseWorksheet(append(rows, "+  *    ", "", "  "), Layout{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"line 3: operator row has trailing spaces out to column 8 but the number rows end at column 5, padding ignored"}
+	if !reflect.DeepEqual(worksheet.Warnings, want) {
+		t.Errorf("warnings = %q, want %q", worksheet.Warnings, want)
+	}
+	if last := worksheet.Problems[1]; last.EndCol != 5 || last.Operator != "*" {
+		t.Errorf("last problem ends at %d with %q, want 5 and \"*\"", last.EndCol, last.Operator)
+	}
+}
+
+func TestParseWorksheetErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		lines  []string
+		layout Layout
+		want   string
+	}{
+		{"no number rows", []string{"+  *", "", " "}, Layout{}, "worksheet needs at least one number row and an operator row"},
+		{"tabs", []string{"1\t2", "+  *"}, Layout{}, "line 1: tab characters make column alignment ambiguous"},
+		{"no operators", []string{"    ", "1  2"}, Layout{OperatorsOnTop: true}, "line 1: operator row has no operators"},
+		{"misaligned column", []string{"1  2", "123 4", "+  *"}, Layout{}, `line 2: '3' at column 3 runs into problem 2, expected a blank separator column`},
+		{"left of first operator", []string{"1 2  3", "  +  *"}, Layout{}, `line 1: "1" is left of the first operator at column 3`},
+		{"two numbers in a cell", []string{"1 2  3", "+    *"}, Layout{}, `line 1: problem 1 has more than one number in "1 2  "`},
+		{"not a number", []string{"1x  3", "+   *"}, Layout{}, `line 1: problem 1: "1x" is not a number`},
+	}
+
+	for _, tt := range tests {
+		_, err := parseWorksheet(tt.lines, tt.layout)
+		if err == nil || err.Error() != tt.want {
+			t.Errorf("%s: error = %v, want %s", tt.name, err, tt.want)
+		}
+	}
+}
+
+func TestSolveFileErrorsNameTheFile(t *testing.T) {
+	_, err := solveFile("missing.txt", HorizontalReading, Options{})
+	if err == nil || !strings.HasPrefix(err.Error(), "missing.txt: ") {
+		t.Errorf("error = %v, want it to start with the filename", err)
+	}
+}
+
+// layoutWorksheets is one small worksheet written down in each layout.
+// Column and digit order only change how part two reads the numbers, so
+// only the operator row position keeps the rows, and part one, as they are.
+var layoutWorksheets = []struct {
+	name     string
+	layout   Layout
+	lines    []string
+	sameRows bool
+}{
+	{"default", Layout{}, []string{
+		" 12 7 ",
+		"345 89",
+		"-   - ",
+	}, true},
+	{"columns ltr", Layout{ColumnsLeftToRight: true}, []string{
+		"21   7",
+		"543 98",
+		"-   - ",
+	}, false},
+	{"digits btt", Layout{DigitsBottomToTop: true}, []string{
+		"345 89",
+		" 12 7 ",
+		"-   - ",
+	}, false},
+	{"operators top", Layout{OperatorsOnTop: true}, []string{
+		"-   - ",
+		" 12 7 ",
+		"345 89",
+	}, true},
+	{"all three", Layout{ColumnsLeftToRight: true, DigitsBottomToTop: true, OperatorsOnTop: true}, []string{
+		"-   - ",
+		"543 98",
+		"21   7",
+	}, false},
+}
+
+func solveLines(t *testing.T, lines []string, layout Layout, reading Reading) *Solution {
+	t.Helper()
+	worksheet, err := parseWorksheet(lines, layout)
+	if err != nil {
+		t.Fatal(err)
+	}
+	solution, err := worksheet.Solve(reading, LeftToRight)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return solution
+}
+
+func TestLayoutsReadTheSameProblems(t *testing.T) {
+	def := layoutWorksheets[0]
+	wantVertical := solveLines(t, def.lines, def.layout, VerticalReading)
+	wantHorizontal := solveLines(t, def.lines, def.layout, HorizontalReading)
+	if wantVertical.GrandTotal != 8-69 || wantHorizontal.GrandTotal != -333-82 {
+		t.Fatalf("default layout totals %d and %d, want -415 and -61", wantHorizontal.GrandTotal, wantVertical.GrandTotal)
+	}
+
+	for _, tt := range layoutWorksheets[1:] {
+		got := solveLines(t, tt.lines, tt.layout, VerticalReading)
+		for k, step := range got.Steps {
+			if want := wantVertical.Steps[k]; !reflect.DeepEqual(step.Numbers, want.Numbers) {
+				t.Errorf("%s: problem %d reads %v, want %v", tt.name, k+1, step.Numbers, want.Numbers)
+			}
+		}
+		if got.GrandTotal != wantVertical.GrandTotal {
+			t.Errorf("%s: part two = %d, want %d", tt.name, got.GrandTotal, wantVertical.GrandTotal)
+		}
+
+		if tt.sameRows {
+			if got := solveLines(t, tt.lines, tt.layout, HorizontalReading); got.GrandTotal != wantHorizontal.GrandTotal {
+				t.Errorf("%s: part one = %d, want %d", tt.name, got.GrandTotal, wantHorizontal.GrandTotal)
+			}
+		}
+	}
+}
+
+func TestExampleWithOperatorsOnTop(t *testing.T) {
+	lines := utilities.LoadInput("example.txt")
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	moved := append([]string{lines[len(lines)-1]}, lines[:len(lines)-1]...)
+
+	for _, reading := range []Reading{HorizontalReading, VerticalReading} {
+		want := solveLines(t, lines, Layout{}, reading).GrandTotal
+		if got := solveLines(t, moved, Layout{OperatorsOnTop: true}, reading).GrandTotal; got != want {
+			t.Errorf("%s: %d with the operators on top, want %d", reading, got, want)
+		}
+	}
+}
+
+func TestParseLayout(t *testing.T) {
+	layout, err := parseLayout("ltr", "btt", "top")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (Layout{ColumnsLeftToRight: true, DigitsBottomToTop: true, OperatorsOnTop: true}); layout != want {
+		t.Errorf("parseLayout(ltr, btt, top) = %+v, want %+v", layout, want)
+	}
+
+	if layout, err := parseLayout("rtl", "ttb", "bottom"); err != nil || layout != (Layout{}) {
+		t.Errorf("parseLayout(rtl, ttb, bottom) = %+v, %v, want the zero layout", layout, err)
+	}
+
+	for _, args := range [][3]string{{"up", "ttb", "bottom"}, {"rtl", "ltr", "bottom"}, {"rtl", "ttb", "left"}} {
+		if _, err := parseLayout(args[0], args[1], args[2]); err == nil {
+			t.Errorf("parseLayout%v: expected an error", args)
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"context"
+
+	"github.com/stephen-condon/advent-of-code-2025/utilities"
+)
+
+type Direction int
+
+const (
+	Up Direction = iota
+	Right
+	Down
+	Left
+)
+
+func (d Direction) delta() (int, int) {
+	switch d {
+	case Up:
+		return -1, 0
+	case Right:
+		return 0, 1
+	case Down:
+		return 1, 0
+	}
+	return 0, -1
+}
+
+func (d Direction) horizontal() bool {
+	return d == Left || d == Right
+}
+
+// Optics elements understood by traceBeams:
+//
+//	.  S   empty space, the beam carries on
+//	^      splitter: the beam carries on in the same direction from the two
+//	       cells either side of it (left and right for a vertical beam)
+//	|      splits a horizontal beam into up and down, vertical beams pass
+//	-      splits a vertical beam into left and right, horizontal beams pass
+//	/  \   mirrors
+//	#      absorber, as is any other character
+var mirrors = map[byte]map[Direction]Direction{
+	'/':  {Right: Up, Up: Right, Left: Down, Down: Left},
+	'\\': {Right: Down, Down: Right, Left: Up, Up: Left},
+}
+
+// BeamResult is what traceBeams learned about the beams' journey
+type BeamResult struct {
+	Splits    int                 // splitters that actually split a beam
+	SplitHits *utilities.CoordSet // splitter cells that split a beam
+	Energized *utilities.CoordSet // cells a beam passed through
+	Exits     []Beam              // where each beam left the grid
+}
+
+func inGrid(grid []string, row, col int) bool {
+	return row >= 0 && row < len(grid) && col >= 0 && col < len(grid[row])
+}
+
+// traceBeams follows beams from each start, which is about to move in its
+// dir, until every beam they spawn has left the grid, been absorbed, or
+// joined a path already taken. A beam state is its position and direction,
+// so beams in loops, or from different starts that merge, are only
+// followed once.
+func traceBeams(grid []string, starts ...Beam) BeamResult {
+	result, _ := traceBeamsContext(context.Background(), grid, starts...)
+	return result
+}
+
+// traceBeamsContext is traceBeams, giving up between steps once ctx is done
+func traceBeamsContext(ctx context.Context, grid []string, starts ...Beam) (BeamResult, error) {
+	width := 0
+	for _, line := range grid {
+		width = max(width, len(line))
+	}
+
+	result := BeamResult{
+		SplitHits: utilities.NewCoordSet(0, 0, len(grid), width, 1),
+		Energized: utilities.NewCoordSet(0, 0, len(grid), width, 1),
+	}
+
+	// Splitters can place a beam one cell outside the grid, just before it
+	// leaves, so the visited states have a one-cell margin
+	visited := utilities.NewCoordSet(-1, -1, len(grid)+2, width+2, 4)
+	var beams []Beam
+	for _, start := range starts {
+		if visited.Add(start.row, start.col, int(start.dir)) {
+			beams = append(beams, start)
+		}
+	}
+
+	enqueue := func(next []Beam, beam Beam) []Beam {
+		if !visited.Add(beam.row, beam.col, int(beam.dir)) {
+			return next
+		}
+		return append(next, beam)
+	}
+
+	for len(beams) > 0 {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+
+		var nextBeams []Beam
+
+		for _, beam := range beams {
+			dRow, dCol := beam.dir.delta()
+			beam.row += dRow
+			beam.col += dCol
+
+			if !inGrid(grid, beam.row, beam.col) {
+				result.Exits = append(result.Exits, beam)
+				continue
+			}
+
+			result.Energized.Add(beam.row, beam.col, 0)
+			cell := grid[beam.row][beam.col]
+
+			switch cell {
+			case '.', 'S':
+				nextBeams = enqueue(nextBeams, beam)
+
+			case '^':
+				result.Splits++
+				result.SplitHits.Add(beam.row, beam.col, 0)
+
+				// Shift sideways relative to the direction of travel. The
+				// new beams light the cells they start in.
+				sRow, sCol := dCol, dRow
+				for _, side := range []Beam{
+					{row: beam.row - sRow, col: beam.col - sCol, dir: beam.dir},
+					{row: beam.row + sRow, col: beam.col + sCol, dir: beam.dir},
+				} {
+					if inGrid(grid, side.row, side.col) {
+						result.Energized.Add(side.row, side.col, 0)
+					}
+					nextBeams = enqueue(nextBeams, side)
+				}
+
+			case '|', '-':
+				if (cell == '|') != beam.dir.horizontal() {
+					nextBeams = enqueue(nextBeams, beam)
+					continue
+				}
+
+				result.Splits++
+				result.SplitHits.Add(beam.row, beam.col, 0)
+
+				first, second := Up, Down
+				if cell == '-' {
+					first, second = Left, Right
+				}
+				nextBeams = enqueue(nextBeams, Beam{row: beam.row, col: beam.col, dir: first})
+				nextBeams = enqueue(nextBeams, Beam{row: beam.row, col: beam.col, dir: second})
+
+			case '/', '\\':
+				beam.dir = mirrors[cell][beam.dir]
+				nextBeams = enqueue(nextBeams, beam)
+			}
+		}
+
+		beams = nextBeams
+	}
+
+	return result, nil
+}
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// sortedExits orders the exits so tests don't depend on the order beams
+// were followed in
+func sortedExits(result BeamResult) []Beam {
+	exits := append([]Beam{}, result.Exits...)
+	sort.Slice(exits, func(i, j int) bool {
+		a, b := exits[i], exits[j]
+		if a.row != b.row {
+			return a.row < b.row
+		}
+		if a.col != b.col {
+			return a.col < b.col
+		}
+		return a.dir < b.dir
+	})
+	return exits
+}
+
+// centre puts one element in the middle of a 3x3 grid of empty space
+func centre(cell byte) []string {
+	return []string{"...", "." + string(cell) + ".", "..."}
+}
+
+// towardsCentre starts a beam outside the 3x3 grid that reaches the middle
+// cell on its second step
+func towardsCentre(dir Direction) Beam {
+	dRow, dCol := dir.delta()
+	return Beam{row: 1 - 2*dRow, col: 1 - 2*dCol, dir: dir}
+}
+
+// awayFromCentre is where a beam leaving the middle cell in dir leaves the
+// 3x3 grid
+func awayFromCentre(dir Direction) Beam {
+	dRow, dCol := dir.delta()
+	return Beam{row: 1 + 2*dRow, col: 1 + 2*dCol, dir: dir}
+}
+
+func TestTraceBeamsEmptySpace(t *testing.T) {
+	for _, dir := range []Direction{Up, Right, Down, Left} {
+		result := traceBeams(centre('.'), towardsCentre(dir))
+		if want := []Beam{awayFromCentre(dir)}; !reflect.DeepEqual(result.Exits, want) {
+			t.Errorf("%v: exits = %v, want %v", dir, result.Exits, want)
+		}
+		if result.Splits != 0 || result.Energized.Len() != 3 {
+			t.Errorf("%v: %d splits and %d energized cells, want 0 and 3", dir, result.Splits, result.Energized.Len())
+		}
+	}
+}
+
+func TestTraceBeamsMirrors(t *testing.T) {
+	turns := map[byte]map[Direction]Direction{
+		'/':  {Right: Up, Up: Right, Left: Down, Down: Left},
+		'\\': {Right: Down, Down: Right, Left: Up, Up: Left},
+	}
+
+	for mirror, turn := range turns {
+		for in, out := range turn {
+			result := traceBeams(centre(mirror), towardsCentre(in))
+			if want := []Beam{awayFromCentre(out)}; !reflect.DeepEqual(result.Exits, want) {
+				t.Errorf("%c heading %v: exits = %v, want %v", mirror, in, result.Exits, want)
+			}
+			if result.Splits != 0 {
+				t.Errorf("%c heading %v: %d splits, want 0", mirror, in, result.Splits)
+			}
+		}
+	}
+}
+
+func TestTraceBeamsSplitters(t *testing.T) {
+	tests := []struct {
+		cell   byte
+		dir    Direction
+		splits int
+		exits  []Beam
+	}{
+		// | splits horizontal beams up and down and lets vertical ones pass
+		{'|', Right, 1, []Beam{{-1, 1, Up}, {3, 1, Down}}},
+		{'|', Left, 1, []Beam{{-1, 1, Up}, {3, 1, Down}}},
+		{'|', Down, 0, []Beam{{3, 1, Down}}},
+		{'|', Up, 0, []Beam{{-1, 1, Up}}},
+
+		// - splits vertical beams left and right and lets horizontal ones pass
+		{'-', Down, 1, []Beam{{1, -1, Left}, {1, 3, Right}}},
+		{'-', Up, 1, []Beam{{1, -1, Left}, {1, 3, Right}}},
+		{'-', Right, 0, []Beam{{1, 3, Right}}},
+		{'-', Left, 0, []Beam{{1, -1, Left}}},
+
+		// ^ carries on in the same direction from either side of itself
+		{'^', Down, 1, []Beam{{3, 0, Down}, {3, 2, Down}}},
+		{'^', Up, 1, []Beam{{-1, 0, Up}, {-1, 2, Up}}},
+		{'^', Right, 1, []Beam{{0, 3, Right}, {2, 3, Right}}},
+		{'^', Left, 1, []Beam{{0, -1, Left}, {2, -1, Left}}},
+	}
+
+	for _, tt := range tests {
+		result := traceBeams(centre(tt.cell), towardsCentre(tt.dir))
+		if got := sortedExits(result); !reflect.DeepEqual(got, tt.exits) {
+			t.Errorf("%c heading %v: exits = %v, want %v", tt.cell, tt.dir, got, tt.exits)
+		}
+		if result.Splits != tt.splits {
+			t.Errorf("%c heading %v: %d splits, want %d", tt.cell, tt.dir, result.Splits, tt.splits)
+		}
+		if got := result.SplitHits.Has(1, 1, 0); got != (tt.splits > 0) {
+			t.Errorf("%c heading %v: splitter hit = %v, want %v", tt.cell, tt.dir, got, tt.splits > 0)
+		}
+	}
+}
+
+func TestTraceBeamsAbsorbers(t *testing.T) {
+	for _, cell := range []byte{'#', 'x'} {
+		result := traceBeams(centre(cell), towardsCentre(Down))
+		if len(result.Exits) != 0 {
+			t.Errorf("%c: exits = %v, want none", cell, result.Exits)
+		}
+		// The absorber is lit, but nothing past it is
+		if !result.Energized.Has(1, 1, 0) || result.Energized.Has(2, 1, 0) {
+			t.Errorf("%c: energized cells are wrong, %d lit", cell, result.Energized.Len())
+		}
+	}
+}
+
+func TestTraceBeamsMirrorLoopTerminates(t *testing.T) {
+	// Splitting at the right edge sends both beams round the ring, where
+	// the mirrors keep them circling forever
+	grid := []string{
+		`/-\`,
+		`|.|`,
+		`\-/`,
+	}
+
+	result := traceBeams(grid, Beam{row: 1, col: 1, dir: Right})
+	if len(result.Exits) != 0 {
+		t.Errorf("exits = %v, want none", result.Exits)
+	}
+	if result.Splits != 1 {
+		t.Errorf("%d splits, want 1", result.Splits)
+	}
+	if result.Energized.Len() != 8 || result.Energized.Has(1, 1, 0) {
+		t.Errorf("%d energized cells, want the 8 in the ring", result.Energized.Len())
+	}
+}
+
+func TestTraceBeamsMergesRepeatedStarts(t *testing.T) {
+	start := towardsCentre(Down)
+	result := traceBeams(centre('^'), start, start)
+	if result.Splits != 1 || len(result.Exits) != 2 {
+		t.Errorf("%d splits and %d exits, want the one beam followed once", result.Splits, len(result.Exits))
+	}
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/stephen-condon/advent-of-code-2025/utilities"
+)
+
+// benchParts splits each part into parsing and solving for -bench
+func benchParts(c utilities.Case) []utilities.BenchPart {
+	return []utilities.BenchPart{
+		{Name: "part one", Parse: func(lines []string) (utilities.Solver, error) {
+			return func() (string, error) {
+				splits, err := simulateBeams(context.Background(), lines)
+				return strconv.Itoa(splits), err
+			}, nil
+		}},
+		{Name: "part two", Parse: func(lines []string) (utilities.Solver, error) {
+			return func() (string, error) {
+				paths, err := countAllPaths(context.Background(), lines)
+				if err != nil {
+					return "", err
+				}
+				return paths.String(), nil
+			}, nil
+		}},
+	}
+}
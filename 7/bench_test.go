@@ -0,0 +1,136 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/stephen-condon/advent-of-code-2025/utilities"
+)
+
+// splitterField generates a size x size grid with the source in the middle
+// of the top row and a splitter on roughly one in density cells of every
+// other row, like the puzzle input
+func splitterField(size, density int) []string {
+	rng := rand.New(rand.NewSource(7))
+	grid := make([]string, size)
+
+	row := make([]byte, size)
+	for r := range grid {
+		for c := range row {
+			row[c] = '.'
+			if r%2 == 0 && r > 0 && rng.Intn(density) == 0 {
+				row[c] = '^'
+			}
+		}
+		if r == 0 {
+			row[size/2] = 'S'
+		}
+		grid[r] = string(row)
+	}
+
+	return grid
+}
+
+// simulateBeamsStringKeys is simulateBeams as it was before the beam engine
+// used utilities.CoordSet, keying visited cells with formatted strings
+func simulateBeamsStringKeys(grid []string) int {
+	startCol := strings.IndexByte(grid[0], 'S')
+	if startCol == -1 {
+		return 0
+	}
+
+	beams := []Beam{{row: 0, col: startCol}}
+	visited := make(map[string]bool)
+	splitCount := 0
+
+	for len(beams) > 0 {
+		var nextBeams []Beam
+
+		for _, beam := range beams {
+			beam.row++
+
+			if beam.row >= len(grid) {
+				continue
+			}
+
+			if beam.col < 0 || beam.col >= len(grid[beam.row]) {
+				continue
+			}
+
+			cell := grid[beam.row][beam.col]
+
+			if cell == '^' {
+				splitCount++
+
+				leftBeam := Beam{row: beam.row, col: beam.col - 1}
+				rightBeam := Beam{row: beam.row, col: beam.col + 1}
+
+				leftKey := fmt.Sprintf("%d,%d", leftBeam.row, leftBeam.col)
+				rightKey := fmt.Sprintf("%d,%d", rightBeam.row, rightBeam.col)
+
+				if !visited[leftKey] {
+					visited[leftKey] = true
+					nextBeams = append(nextBeams, leftBeam)
+				}
+				if !visited[rightKey] {
+					visited[rightKey] = true
+					nextBeams = append(nextBeams, rightBeam)
+				}
+			} else if cell == '.' {
+				beamKey := fmt.Sprintf("%d,%d", beam.row, beam.col)
+				if !visited[beamKey] {
+					visited[beamKey] = true
+					nextBeams = append(nextBeams, beam)
+				}
+			}
+		}
+
+		beams = nextBeams
+	}
+
+	return splitCount
+}
+
+func TestSimulateBeamsMatchesStringKeys(t *testing.T) {
+	grid := splitterField(300, 8)
+	got, err := simulateBeams(context.Background(), grid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := simulateBeamsStringKeys(grid); got != want {
+		t.Errorf("simulateBeams = %d, string-keyed version gives %d", got, want)
+	}
+}
+
+// Run with: go test -bench SplitterField -benchmem
+func BenchmarkSplitterField(b *testing.B) {
+	if testing.Short() {
+		b.Skip("10,000 x 10,000 field")
+	}
+	grid := splitterField(10000, 8)
+
+	b.Run("CoordSet", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			simulateBeams(context.Background(), grid)
+		}
+	})
+
+	b.Run("StringKeys", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			simulateBeamsStringKeys(grid)
+		}
+	})
+}
+
+func BenchmarkPartOne(b *testing.B) {
+	utilities.BenchmarkCase(b, "input.txt", 1, benchParts)
+}
+
+func BenchmarkPartTwo(b *testing.B) {
+	utilities.BenchmarkCase(b, "input.txt", 2, benchParts)
+}
@@ -1,162 +1,302 @@
 package main
 
 import (
+	"context"
+	"flag"
 	"fmt"
+	"math/big"
+	"os"
+	"strconv"
 
 	"github.com/stephen-condon/advent-of-code-2025/utilities"
 )
 
 type Beam struct {
 	row, col int
+	dir      Direction
 }
 
 func main() {
+	render := flag.Bool("render", false, "draw the beam trail over the grid using ANSI colour")
+	svgFile := flag.String("svg", "", "write the beam trail as an SVG image to this file")
+	histogram := flag.Bool("histogram", false, "print the number of paths leaving through each column")
+	inputFile := flag.String("input", "example.txt", "grid to render or histogram")
+	bench := utilities.AddBenchFlags(flag.CommandLine)
+	profile := utilities.AddProfileFlags(flag.CommandLine)
+	selection := utilities.AddSelectionFlags(flag.CommandLine)
+	timeout := flag.Duration("timeout", 0, "give up on a part after this long and report TIMEOUT, 0 for no limit")
+	flag.Parse()
+
+	if *render || *svgFile != "" || *histogram {
+		if err := visualise(*inputFile, *render, *svgFile, *histogram); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	cases, err := utilities.LoadCases(".")
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	if bench.Enabled() {
+		if err := bench.Run("day7", cases, benchParts); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	// Exit non-zero if any part fails, so that run-all and scripts notice
+	failed := false
+
 	fmt.Println("Part One:")
-	executePartOne("example.txt")
-	executePartOne("input.txt")
+	for _, c := range selection.Cases(cases, 1) {
+		answer := profile.Run("day7", c, 1, func() string {
+			ctx, cancel := utilities.PartContext(*timeout)
+			defer cancel()
+			return executePartOne(ctx, c.File)
+		})
+		if answer == "" || !utilities.CheckAnswer(c, 1, answer) {
+			failed = true
+		}
+	}
 
 	fmt.Println("\nPart Two:")
-	executePartTwo("example.txt")
-	executePartTwo("input.txt")
+	for _, c := range selection.Cases(cases, 2) {
+		answer := profile.Run("day7", c, 2, func() string {
+			ctx, cancel := utilities.PartContext(*timeout)
+			defer cancel()
+			return executePartTwo(ctx, c.File)
+		})
+		if answer == "" || !utilities.CheckAnswer(c, 2, answer) {
+			failed = true
+		}
+	}
+
+	if failed {
+		os.Exit(1)
+	}
 }
 
-func executePartOne(filename string) {
+func executePartOne(ctx context.Context, filename string) string {
 	input := utilities.LoadInput(filename)
 	if len(input) == 0 {
 		fmt.Println("No input found")
-		return
+		return ""
+	}
+
+	splitCount, err := simulateBeams(ctx, input)
+	if err != nil {
+		utilities.PrintPartError(filename, err)
+		return ""
+	}
+
+	// Break the total down when beams from several sources share the grid
+	if sources := findSources(input); len(sources) > 1 {
+		for _, source := range sources {
+			result, err := traceBeamsContext(ctx, input, source)
+			if err != nil {
+				utilities.PrintPartError(filename, err)
+				return ""
+			}
+			fmt.Printf("  source %d,%d: %d splits, exits at columns %v\n",
+				source.row, source.col, result.Splits, exitColumnList(result))
+		}
 	}
 
-	splitCount := simulateBeams(input)
 	fmt.Printf("%s: %d\n", filename, splitCount)
+	return strconv.Itoa(splitCount)
 }
 
-func executePartTwo(filename string) {
+func visualise(filename string, render bool, svgFile string, histogram bool) error {
 	input := utilities.LoadInput(filename)
 	if len(input) == 0 {
-		fmt.Println("No input found")
-		return
+		return fmt.Errorf("No input found")
 	}
 
-	pathCount := countAllPaths(input)
-	fmt.Printf("%s: %d\n", filename, pathCount)
-}
+	sources := findSources(input)
+	if len(sources) == 0 {
+		return fmt.Errorf("%s: %v", filename, errNoSource)
+	}
+	result := traceBeams(input, sources...)
 
-func countAllPaths(grid []string) int {
-	startCol := -1
-	for col := 0; col < len(grid[0]); col++ {
-		if grid[0][col] == 'S' {
-			startCol = col
-			break
-		}
+	if render {
+		renderANSI(os.Stdout, input, result)
 	}
 
-	if startCol == -1 {
-		return 0
+	if svgFile != "" {
+		f, err := os.Create(svgFile)
+		if err != nil {
+			return err
+		}
+		err = renderSVG(f, input, result)
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Wrote %s\n", svgFile)
 	}
 
-	// Use memoization to cache path counts from each state
-	// Key: "row,col,direction" where direction is the choice made at last splitter
-	memo := make(map[string]int)
+	if histogram {
+		writeExitHistogram(os.Stdout, countPathsByExit(input, sources))
+	}
 
-	var countPaths func(row, col int, fromDirection string) int
-	countPaths = func(row, col int, fromDirection string) int {
-		row++
+	return nil
+}
 
-		if row >= len(grid) {
-			return 1
-		}
+func executePartTwo(ctx context.Context, filename string) string {
+	input := utilities.LoadInput(filename)
+	if len(input) == 0 {
+		fmt.Println("No input found")
+		return ""
+	}
 
-		if col < 0 || col >= len(grid[row]) {
-			return 1
-		}
+	pathCount, err := countAllPaths(ctx, input)
+	if err != nil {
+		utilities.PrintPartError(filename, err)
+		return ""
+	}
 
-		key := fmt.Sprintf("%d,%d,%s", row, col, fromDirection)
-		if count, exists := memo[key]; exists {
-			return count
+	if sources := findSources(input); len(sources) > 1 {
+		for _, source := range sources {
+			exits, err := countPathsByExitContext(ctx, input, []Beam{source})
+			if err != nil {
+				utilities.PrintPartError(filename, err)
+				return ""
+			}
+			fmt.Printf("  source %d,%d: %s paths, exits at columns %v\n",
+				source.row, source.col, sumCounts(exits), sortedColumns(exits))
 		}
+	}
 
-		cell := grid[row][col]
-		totalPaths := 0
+	fmt.Printf("%s: %s\n", filename, pathCount)
+	return pathCount.String()
+}
 
-		if cell == '^' {
-			// At a splitter, we have two choices: go left or go right
-			// Count paths from both choices
-			leftPaths := countPaths(row, col-1, "L")
-			rightPaths := countPaths(row, col+1, "R")
-			totalPaths = leftPaths + rightPaths
-		} else if cell == '.' {
-			totalPaths = countPaths(row, col, fromDirection)
-		}
+// countAllPaths counts every timeline the tachyons can take through the
+// grid, summed over all sources
+func countAllPaths(ctx context.Context, grid []string) (*big.Int, error) {
+	sources := findSources(grid)
+	if len(sources) == 0 {
+		return nil, errNoSource
+	}
+	exits, err := countPathsByExitContext(ctx, grid, sources)
+	if err != nil {
+		return nil, err
+	}
+	return sumCounts(exits), nil
+}
 
-		memo[key] = totalPaths
-		return totalPaths
+func sumCounts(exits map[int]*big.Int) *big.Int {
+	total := new(big.Int)
+	for _, n := range exits {
+		total.Add(total, n)
 	}
+	return total
+}
 
-	return countPaths(0, startCol, "START")
+// countPathsByExit carries the number of timelines at each column down the
+// grid one row at a time and tallies them by the column they leave from.
+// Each source starts one timeline heading down from its row; other sources
+// are empty space. A timeline ends when it falls off the bottom or is split
+// off either side of the grid (column -1 or the grid width). Counts are
+// arbitrary precision so tall splitter fields can't overflow.
+func countPathsByExit(grid []string, sources []Beam) map[int]*big.Int {
+	exits, _ := countPathsByExitContext(context.Background(), grid, sources)
+	return exits
 }
 
-func simulateBeams(grid []string) int {
-	startCol := -1
-	for col := 0; col < len(grid[0]); col++ {
-		if grid[0][col] == 'S' {
-			startCol = col
-			break
+// countPathsByExitContext is countPathsByExit, giving up between rows once
+// ctx is done
+func countPathsByExitContext(ctx context.Context, grid []string, sources []Beam) (map[int]*big.Int, error) {
+	exits := make(map[int]*big.Int)
+	exit := func(col int, n *big.Int) {
+		if exits[col] == nil {
+			exits[col] = new(big.Int)
 		}
+		exits[col].Add(exits[col], n)
 	}
 
-	if startCol == -1 {
-		return 0
+	width := 0
+	for _, line := range grid {
+		width = max(width, len(line))
 	}
 
-	beams := []Beam{{row: 0, col: startCol}}
-	visited := make(map[string]bool)
-	splitCount := 0
+	// counts[col+1] holds the timelines at col, leaving room for a beam
+	// split off either edge
+	counts := make([]*big.Int, width+2)
+	add := func(counts []*big.Int, col int, n *big.Int) {
+		if counts[col+1] == nil {
+			counts[col+1] = new(big.Int)
+		}
+		counts[col+1].Add(counts[col+1], n)
+	}
+
+	sourcesByRow := make(map[int][]int)
+	for _, source := range sources {
+		sourcesByRow[source.row] = append(sourcesByRow[source.row], source.col)
+	}
 
-	for len(beams) > 0 {
-		var nextBeams []Beam
+	for row := 0; row < len(grid); row++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		for _, col := range sourcesByRow[row] {
+			add(counts, col, big.NewInt(1))
+		}
 
-		for _, beam := range beams {
-			beam.row++
+		if row == len(grid)-1 {
+			break
+		}
 
-			if beam.row >= len(grid) {
+		next := make([]*big.Int, width+2)
+		for i, n := range counts {
+			if n == nil {
 				continue
 			}
+			col := i - 1
 
-			if beam.col < 0 || beam.col >= len(grid[beam.row]) {
+			if col < 0 || col >= len(grid[row+1]) {
+				exit(col, n)
 				continue
 			}
 
-			cell := grid[beam.row][beam.col]
-
-			if cell == '^' {
-				splitCount++
-
-				leftBeam := Beam{row: beam.row, col: beam.col - 1}
-				rightBeam := Beam{row: beam.row, col: beam.col + 1}
-
-				leftKey := fmt.Sprintf("%d,%d", leftBeam.row, leftBeam.col)
-				rightKey := fmt.Sprintf("%d,%d", rightBeam.row, rightBeam.col)
-
-				if !visited[leftKey] {
-					visited[leftKey] = true
-					nextBeams = append(nextBeams, leftBeam)
-				}
-				if !visited[rightKey] {
-					visited[rightKey] = true
-					nextBeams = append(nextBeams, rightBeam)
-				}
-			} else if cell == '.' {
-				beamKey := fmt.Sprintf("%d,%d", beam.row, beam.col)
-				if !visited[beamKey] {
-					visited[beamKey] = true
-					nextBeams = append(nextBeams, beam)
-				}
+			switch grid[row+1][col] {
+			case '^':
+				add(next, col-1, n)
+				add(next, col+1, n)
+			case '.', 'S':
+				add(next, col, n)
 			}
 		}
 
-		beams = nextBeams
+		counts = next
+	}
+
+	// Everything still travelling leaves through the bottom
+	for i, n := range counts {
+		if n != nil {
+			exit(i-1, n)
+		}
+	}
+
+	return exits, nil
+}
+
+// simulateBeams counts the splits made by beams fired down from every S,
+// with beams from different sources merging where their paths meet
+func simulateBeams(ctx context.Context, grid []string) (int, error) {
+	sources := findSources(grid)
+	if len(sources) == 0 {
+		return 0, errNoSource
 	}
 
-	return splitCount
+	result, err := traceBeamsContext(ctx, grid, sources...)
+	return result.Splits, err
 }
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stephen-condon/advent-of-code-2025/utilities"
+)
+
+// countAllPathsRecursive is the original memoised search that
+// countAllPaths replaced, kept as it was to cross-check it
+func countAllPathsRecursive(grid []string) int {
+	startCol := -1
+	for col := 0; col < len(grid[0]); col++ {
+		if grid[0][col] == 'S' {
+			startCol = col
+			break
+		}
+	}
+
+	if startCol == -1 {
+		return 0
+	}
+
+	// Use memoization to cache path counts from each state
+	// Key: "row,col,direction" where direction is the choice made at last splitter
+	memo := make(map[string]int)
+
+	var countPaths func(row, col int, fromDirection string) int
+	countPaths = func(row, col int, fromDirection string) int {
+		row++
+
+		if row >= len(grid) {
+			return 1
+		}
+
+		if col < 0 || col >= len(grid[row]) {
+			return 1
+		}
+
+		key := fmt.Sprintf("%d,%d,%s", row, col, fromDirection)
+		if count, exists := memo[key]; exists {
+			return count
+		}
+
+		cell := grid[row][col]
+		totalPaths := 0
+
+		if cell == '^' {
+			// At a splitter, we have two choices: go left or go right
+			// Count paths from both choices
+			leftPaths := countPaths(row, col-1, "L")
+			rightPaths := countPaths(row, col+1, "R")
+			totalPaths = leftPaths + rightPaths
+		} else if cell == '.' {
+			totalPaths = countPaths(row, col, fromDirection)
+		}
+
+		memo[key] = totalPaths
+		return totalPaths
+	}
+
+	return countPaths(0, startCol, "START")
+}
+
+func TestCountAllPathsMatchesRecursion(t *testing.T) {
+	for _, filename := range []string{"example.txt", "input.txt"} {
+		grid := utilities.LoadInput(filename)
+
+		got, err := countAllPaths(context.Background(), grid)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := countAllPathsRecursive(grid)
+		if !got.IsInt64() || got.Int64() != int64(want) {
+			t.Errorf("%s: countAllPaths = %s, recursion gives %d", filename, got, want)
+		}
+	}
+}
+
+func TestCountAllPathsExample(t *testing.T) {
+	got, err := countAllPaths(context.Background(), utilities.LoadInput("example.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Int64() != 40 {
+		t.Errorf("countAllPaths(example.txt) = %s, want 40", got)
+	}
+}
+
+func TestCancelledContextStopsBothParts(t *testing.T) {
+	grid := splitterField(2000, 8)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := simulateBeams(ctx, grid); !errors.Is(err, context.Canceled) {
+		t.Errorf("simulateBeams error = %v, want context.Canceled", err)
+	}
+	if _, err := countAllPaths(ctx, grid); !errors.Is(err, context.Canceled) {
+		t.Errorf("countAllPaths error = %v, want context.Canceled", err)
+	}
+}
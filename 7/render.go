@@ -0,0 +1,158 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math/big"
+	"strings"
+)
+
+const (
+	ansiReset     = "\033[0m"
+	ansiTrail     = "\033[33m"   // yellow
+	ansiSplit     = "\033[1;31m" // bold red
+	ansiExit      = "\033[32m"   // green
+	svgCell       = 12
+	svgBackground = "#0f0f23"
+)
+
+// overlayTrail copies the grid with every energised empty cell replaced by
+// the beam trail. Trailing blank lines are dropped.
+func overlayTrail(grid []string, result BeamResult) [][]byte {
+	for len(grid) > 0 && strings.TrimSpace(grid[len(grid)-1]) == "" {
+		grid = grid[:len(grid)-1]
+	}
+
+	overlay := make([][]byte, len(grid))
+	for row, line := range grid {
+		overlay[row] = []byte(line)
+		for col := range overlay[row] {
+			if overlay[row][col] == '.' && result.Energized.Has(row, col, 0) {
+				overlay[row][col] = '|'
+			}
+		}
+	}
+	return overlay
+}
+
+// exitColumns lists the columns where beams left through the bottom
+func exitColumns(result BeamResult) map[int]bool {
+	cols := make(map[int]bool)
+	for _, exit := range result.Exits {
+		if exit.dir == Down {
+			cols[exit.col] = true
+		}
+	}
+	return cols
+}
+
+// renderANSI prints the grid with the trail, the splitters that were hit
+// and a marker row under the exit columns, coloured for a terminal
+func renderANSI(w io.Writer, grid []string, result BeamResult) {
+	overlay := overlayTrail(grid, result)
+	width := 0
+
+	for row, line := range overlay {
+		width = max(width, len(line))
+		var sb strings.Builder
+		for col, cell := range line {
+			switch {
+			case cell == '|' && grid[row][col] == '.':
+				sb.WriteString(ansiTrail + "|" + ansiReset)
+			case result.SplitHits.Has(row, col, 0):
+				sb.WriteString(ansiSplit + string(cell) + ansiReset)
+			default:
+				sb.WriteByte(cell)
+			}
+		}
+		fmt.Fprintln(w, sb.String())
+	}
+
+	exits := exitColumns(result)
+	var sb strings.Builder
+	for col := 0; col < width; col++ {
+		if exits[col] {
+			sb.WriteString(ansiExit + "v" + ansiReset)
+		} else {
+			sb.WriteByte(' ')
+		}
+	}
+	fmt.Fprintln(w, sb.String())
+}
+
+// renderSVG draws the same overlay as renderANSI as an SVG image
+func renderSVG(out io.Writer, grid []string, result BeamResult) error {
+	w := bufio.NewWriter(out)
+	overlay := overlayTrail(grid, result)
+	width := 0
+	for _, line := range overlay {
+		width = max(width, len(line))
+	}
+	height := len(overlay) + 1 // one extra row for the exit markers
+
+	fmt.Fprintf(w, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" font-family="monospace" font-size="%d">`+"\n",
+		width*svgCell, height*svgCell, svgCell-2)
+	fmt.Fprintf(w, `<rect width="100%%" height="100%%" fill="%s"/>`+"\n", svgBackground)
+
+	for row, line := range overlay {
+		for col, cell := range line {
+			x, y := col*svgCell, row*svgCell
+			switch {
+			case cell == '|' && grid[row][col] == '.':
+				fmt.Fprintf(w, `<rect x="%d" y="%d" width="%d" height="%d" fill="#ffff66" opacity="0.6"/>`+"\n",
+					x+svgCell/2-1, y, 2, svgCell)
+			case result.SplitHits.Has(row, col, 0):
+				fmt.Fprintf(w, `<rect x="%d" y="%d" width="%d" height="%d" fill="#ff4444"/>`+"\n", x, y, svgCell, svgCell)
+				fmt.Fprintf(w, `<text x="%d" y="%d" fill="#ffffff">%s</text>`+"\n", x+2, y+svgCell-2, svgEscape(cell))
+			case cell != '.':
+				fmt.Fprintf(w, `<text x="%d" y="%d" fill="#cccccc">%s</text>`+"\n", x+2, y+svgCell-2, svgEscape(cell))
+			}
+		}
+	}
+
+	exits := exitColumns(result)
+	for col := 0; col < width; col++ {
+		if exits[col] {
+			fmt.Fprintf(w, `<circle cx="%d" cy="%d" r="%d" fill="#00cc00"/>`+"\n",
+				col*svgCell+svgCell/2, len(overlay)*svgCell+svgCell/2, svgCell/3)
+		}
+	}
+
+	fmt.Fprintln(w, "</svg>")
+	return w.Flush()
+}
+
+func svgEscape(cell byte) string {
+	switch cell {
+	case '<':
+		return "&lt;"
+	case '>':
+		return "&gt;"
+	case '&':
+		return "&amp;"
+	}
+	return string(cell)
+}
+
+// writeExitHistogram prints how many timelines leave through each column,
+// with a bar scaled to the busiest column
+func writeExitHistogram(w io.Writer, exits map[int]*big.Int) {
+	busiest := new(big.Int)
+	for _, n := range exits {
+		if n.Cmp(busiest) > 0 {
+			busiest = n
+		}
+	}
+
+	const barWidth = 40
+	for _, col := range sortedColumns(exits) {
+		n := exits[col]
+		bar := 0
+		if busiest.Sign() > 0 {
+			scaled := new(big.Int).Mul(n, big.NewInt(barWidth))
+			bar = int(scaled.Quo(scaled, busiest).Int64())
+		}
+		fmt.Fprintf(w, "%4d %-*s %s\n", col, barWidth, strings.Repeat("#", bar), n)
+	}
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stephen-condon/advent-of-code-2025/utilities"
+)
+
+var ansiCodes = regexp.MustCompile("\033\\[[0-9;]*m")
+
+func traceExample(t *testing.T) ([]string, BeamResult) {
+	t.Helper()
+	grid := utilities.LoadInput("example.txt")
+	sources := findSources(grid)
+	if len(sources) == 0 {
+		t.Fatal(errNoSource)
+	}
+	return grid, traceBeams(grid, sources...)
+}
+
+func TestRenderANSIExample(t *testing.T) {
+	grid, result := traceExample(t)
+
+	var b strings.Builder
+	renderANSI(&b, grid, result)
+
+	want := "" +
+		".......S.......\n" +
+		".......|.......\n" +
+		"......|^|......\n" +
+		"......|.|......\n" +
+		".....|^|^|.....\n" +
+		".....|.|.|.....\n" +
+		"....|^|^|^|....\n" +
+		"....|.|.|.|....\n" +
+		"...|^|^|||^|...\n" +
+		"...|.|.|||.|...\n" +
+		"..|^|^|||^|^|..\n" +
+		"..|.|.|||.|.|..\n" +
+		".|^|||^||.||^|.\n" +
+		".|.|||.||.||.|.\n" +
+		"|^|^|^|^|^|||^|\n" +
+		"|.|.|.|.|.|||.|\n" +
+		"v v v v v vvv v\n"
+	if got := ansiCodes.ReplaceAllString(b.String(), ""); got != want {
+		t.Errorf("render:\n%s\nwant:\n%s", got, want)
+	}
+
+	if got := strings.Count(b.String(), ansiSplit); got != result.Splits {
+		t.Errorf("%d splitters highlighted, want %d", got, result.Splits)
+	}
+}
+
+func TestWriteExitHistogramExample(t *testing.T) {
+	grid := utilities.LoadInput("example.txt")
+
+	var b strings.Builder
+	writeExitHistogram(&b, countPathsByExit(grid, findSources(grid)))
+
+	bar := func(n int) string { return strings.Repeat("#", n) + strings.Repeat(" ", 40-n) }
+	want := "" +
+		"   0 " + bar(3) + " 1\n" +
+		"   2 " + bar(7) + " 2\n" +
+		"   4 " + bar(36) + " 10\n" +
+		"   6 " + bar(40) + " 11\n" +
+		"   8 " + bar(40) + " 11\n" +
+		"  10 " + bar(7) + " 2\n" +
+		"  11 " + bar(3) + " 1\n" +
+		"  12 " + bar(3) + " 1\n" +
+		"  14 " + bar(3) + " 1\n"
+	if b.String() != want {
+		t.Errorf("histogram:\n%s\nwant:\n%s", b.String(), want)
+	}
+}
+
+func TestRenderSVGExample(t *testing.T) {
+	grid, result := traceExample(t)
+
+	var b strings.Builder
+	if err := renderSVG(&b, grid, result); err != nil {
+		t.Fatal(err)
+	}
+	svg := b.String()
+
+	if !strings.HasPrefix(svg, `<svg xmlns="http://www.w3.org/2000/svg" width="180" height="204"`) || !strings.HasSuffix(svg, "</svg>\n") {
+		t.Fatalf("not a 15 x 17 cell SVG:\n%s", svg)
+	}
+
+	// Every splitter that was hit gets a red cell, and only those
+	hits := 0
+	for row, line := range grid {
+		for col := range len(line) {
+			rect := fmt.Sprintf(`<rect x="%d" y="%d" width="%d" height="%d" fill="#ff4444"/>`, col*svgCell, row*svgCell, svgCell, svgCell)
+			if result.SplitHits.Has(row, col, 0) {
+				hits++
+				if !strings.Contains(svg, rect) {
+					t.Errorf("no highlight for the splitter hit at %d,%d", row, col)
+				}
+			}
+		}
+	}
+	if got := strings.Count(svg, `fill="#ff4444"`); got != hits || hits != result.Splits {
+		t.Errorf("%d splitter highlights for %d hits and %d splits", got, hits, result.Splits)
+	}
+
+	// A marker under each column the beams leave through, left to right
+	var circles []string
+	for _, col := range []int{0, 2, 4, 6, 8, 10, 11, 12, 14} {
+		circles = append(circles, fmt.Sprintf(`<circle cx="%d" cy="%d" r="%d" fill="#00cc00"/>`, col*svgCell+svgCell/2, 16*svgCell+svgCell/2, svgCell/3))
+	}
+	if !strings.Contains(svg, strings.Join(circles, "\n")) {
+		t.Errorf("exit markers missing or out of order:\n%s", svg)
+	}
+	if got := strings.Count(svg, "<circle"); got != len(circles) {
+		t.Errorf("%d exit markers, want %d", got, len(circles))
+	}
+}
+
+func TestRenderSVGReportsWriteErrors(t *testing.T) {
+	grid, result := traceExample(t)
+	if err := renderSVG(failingWriter{}, grid, result); err == nil {
+		t.Error("renderSVG reported success writing to a failing writer")
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("disk full")
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"errors"
+	"math/big"
+	"sort"
+)
+
+var errNoSource = errors.New("no source (S) found in the grid")
+
+// findSources returns a downward beam for every S in the grid, in reading
+// order
+func findSources(grid []string) []Beam {
+	var sources []Beam
+	for row, line := range grid {
+		for col := 0; col < len(line); col++ {
+			if line[col] == 'S' {
+				sources = append(sources, Beam{row: row, col: col, dir: Down})
+			}
+		}
+	}
+	return sources
+}
+
+// exitColumnList lists the distinct columns beams left the grid through,
+// including -1 and the grid width for beams leaving sideways
+func exitColumnList(result BeamResult) []int {
+	seen := make(map[int]bool)
+	var cols []int
+	for _, exit := range result.Exits {
+		if !seen[exit.col] {
+			seen[exit.col] = true
+			cols = append(cols, exit.col)
+		}
+	}
+	sort.Ints(cols)
+	return cols
+}
+
+func sortedColumns(exits map[int]*big.Int) []int {
+	cols := make([]int, 0, len(exits))
+	for col := range exits {
+		cols = append(cols, col)
+	}
+	sort.Ints(cols)
+	return cols
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// Two sources, the second below the top row. Their beams meet at column 4
+// and share the splitter under it.
+var twoSources = []string{
+	"..S......",
+	"......S..",
+	"..^...^..",
+	".........",
+	"...^.^...",
+	".........",
+	"....^....",
+	".........",
+}
+
+func TestFindSources(t *testing.T) {
+	want := []Beam{{row: 0, col: 2, dir: Down}, {row: 1, col: 6, dir: Down}}
+	if got := findSources(twoSources); !reflect.DeepEqual(got, want) {
+		t.Errorf("findSources = %v, want %v", got, want)
+	}
+}
+
+func TestTwoSourcesPerSourceAndMerged(t *testing.T) {
+	sources := findSources(twoSources)
+	perSource := []struct {
+		splits int
+		paths  int64
+		exits  []int
+	}{
+		{3, 4, []int{1, 2, 3, 5}},
+		{3, 4, []int{3, 5, 6, 7}},
+	}
+
+	for k, source := range sources {
+		want := perSource[k]
+		result := traceBeams(twoSources, source)
+		if result.Splits != want.splits {
+			t.Errorf("source %d: %d splits, want %d", k+1, result.Splits, want.splits)
+		}
+		if got := exitColumnList(result); !reflect.DeepEqual(got, want.exits) {
+			t.Errorf("source %d: exit columns %v, want %v", k+1, got, want.exits)
+		}
+
+		exits := countPathsByExit(twoSources, []Beam{source})
+		if got := sumCounts(exits); got.Int64() != want.paths {
+			t.Errorf("source %d: %s paths, want %d", k+1, got, want.paths)
+		}
+		if got := sortedColumns(exits); !reflect.DeepEqual(got, want.exits) {
+			t.Errorf("source %d: paths leave through %v, want %v", k+1, got, want.exits)
+		}
+	}
+
+	// The shared splitter only splits the merged beam once, but every
+	// timeline still counts
+	splits, err := simulateBeams(context.Background(), twoSources)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if splits != 5 {
+		t.Errorf("merged splits = %d, want 5", splits)
+	}
+
+	paths, err := countAllPaths(context.Background(), twoSources)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if paths.Int64() != 8 {
+		t.Errorf("merged paths = %s, want 8", paths)
+	}
+}
+
+func TestNoSource(t *testing.T) {
+	grid := []string{"...", ".^.", "..."}
+
+	if _, err := simulateBeams(context.Background(), grid); !errors.Is(err, errNoSource) {
+		t.Errorf("simulateBeams error = %v, want errNoSource", err)
+	}
+	if _, err := countAllPaths(context.Background(), grid); !errors.Is(err, errNoSource) {
+		t.Errorf("countAllPaths error = %v, want errNoSource", err)
+	}
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"strconv"
+
+	"github.com/stephen-condon/advent-of-code-2025/utilities"
+)
+
+// benchParts splits each part into parsing and solving for -bench
+func benchParts(c utilities.Case, opts Options) []utilities.BenchPart {
+	return []utilities.BenchPart{
+		{Name: "part one", Parse: func(lines []string) (utilities.Solver, error) {
+			connections, err := caseConnections(c)
+			if err != nil {
+				return nil, err
+			}
+			points := parsePoints(lines)
+			return func() (string, error) {
+				return strconv.Itoa(circuitResult(points, connections, opts).Answer()), nil
+			}, nil
+		}},
+		{Name: "part two", Parse: func(lines []string) (utilities.Solver, error) {
+			points := parsePoints(lines)
+			return func() (string, error) {
+				return strconv.Itoa(unifyingResult(points, opts).Answer()), nil
+			}, nil
+		}},
+	}
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"fmt"
+	"iter"
+
+	"github.com/stephen-condon/advent-of-code-2025/utilities"
+)
+
+// KruskalEvent describes one edge considered while connecting boxes
+// closest first
+type KruskalEvent struct {
+	Step       int  // 1-based number of edges considered so far
+	Edge       Edge // the edge considered at this step
+	Merged     bool // false if the boxes were already in the same circuit
+	Size       int  // size of the circuit holding the edge afterwards
+	Components int  // circuits remaining afterwards
+	Largest    int  // size of the largest circuit afterwards
+}
+
+// kruskalEvents yields an event for every pair of boxes, closest first,
+// as Kruskal's algorithm considers it. Pairs are only computed as the
+// caller asks for them, so stop ranging once the question is answered:
+//
+//	for event := range kruskalEvents(points, opts) {
+//		if event.Largest*2 >= len(points) {
+//			fmt.Println(event.Step)
+//			break
+//		}
+//	}
+func kruskalEvents(points []Point, opts Options) iter.Seq[KruskalEvent] {
+	return func(yield func(KruskalEvent) bool) {
+		next := pairSource(points, opts)
+		uf := NewUnionFind(len(points))
+		event := KruskalEvent{Components: len(points), Largest: min(len(points), 1)}
+
+		for {
+			edge, ok := next()
+			if !ok {
+				return
+			}
+
+			event.Step++
+			event.Edge = edge
+			event.Merged = uf.Union(edge.i, edge.j)
+			event.Size = uf.size[uf.Find(edge.i)]
+			if event.Merged {
+				event.Components--
+				event.Largest = max(event.Largest, event.Size)
+			}
+
+			if !yield(event) {
+				return
+			}
+		}
+	}
+}
+
+// pairSource returns a function handing out pairs closest first, either
+// from the fully sorted list or lazily from the k-d tree
+func pairSource(points []Point, opts Options) func() (Edge, bool) {
+	if opts.BruteForce {
+		edges := sortedPairEdges(points, opts.Metric)
+		return func() (Edge, bool) {
+			if len(edges) == 0 {
+				return Edge{}, false
+			}
+			edge := edges[0]
+			edges = edges[1:]
+			return edge, true
+		}
+	}
+	return NewPairStream(points, opts.Metric).Next
+}
+
+// printEvents writes the event log for a file, stopping after limit events
+// or, if limit is 0, once every box is in one circuit
+func printEvents(filename string, limit int, opts Options) error {
+	points := parsePoints(utilities.LoadInput(filename))
+	if len(points) == 0 {
+		return fmt.Errorf("%s: no junction boxes found", filename)
+	}
+
+	for event := range kruskalEvents(points, opts) {
+		outcome := "redundant"
+		if event.Merged {
+			outcome = "merged"
+		}
+		from, to := points[event.Edge.i], points[event.Edge.j]
+		fmt.Printf("#%d %d,%d,%d - %d,%d,%d length %.2f: %s, circuit size %d, %d circuits, largest %d\n",
+			event.Step, from.x, from.y, from.z, to.x, to.y, to.z, opts.Metric.Length(event.Edge.distance),
+			outcome, event.Size, event.Components, event.Largest)
+
+		if event.Step == limit || (limit == 0 && event.Components == 1) {
+			break
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,185 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sort"
+
+	"github.com/stephen-condon/advent-of-code-2025/utilities"
+)
+
+// Forest is the wiring left after a set of connections, ready to export
+type Forest struct {
+	Nodes   []ForestNode  `json:"nodes"`
+	Edges   []ForestEdge  `json:"edges"`
+	Summary ForestSummary `json:"summary"`
+}
+
+type ForestNode struct {
+	ID        int `json:"id"`
+	X         int `json:"x"`
+	Y         int `json:"y"`
+	Z         int `json:"z"`
+	Component int `json:"component"`
+}
+
+type ForestEdge struct {
+	From     int     `json:"from"`
+	To       int     `json:"to"`
+	Length   float64 `json:"length"`
+	Distance int64   `json:"distance"` // exact value from Metric.Distance
+}
+
+type ForestSummary struct {
+	Components      int         `json:"components"`
+	Sizes           map[int]int `json:"sizes"` // circuit size -> number of circuits that size
+	TotalWireLength float64     `json:"totalWireLength"`
+}
+
+// buildForest describes the circuits formed by the given connections.
+// Components are numbered in order of their lowest box.
+func buildForest(points []Point, edges []Edge, metric Metric) Forest {
+	uf := NewUnionFind(len(points))
+	for _, edge := range edges {
+		uf.Union(edge.i, edge.j)
+	}
+
+	forest := Forest{
+		Nodes: make([]ForestNode, len(points)),
+		Edges: make([]ForestEdge, len(edges)),
+		Summary: ForestSummary{
+			Sizes: make(map[int]int),
+		},
+	}
+
+	componentOf := make(map[int]int)
+	for i, p := range points {
+		root := uf.Find(i)
+		component, ok := componentOf[root]
+		if !ok {
+			component = len(componentOf)
+			componentOf[root] = component
+			forest.Summary.Sizes[uf.size[root]]++
+		}
+		forest.Nodes[i] = ForestNode{ID: i, X: p.x, Y: p.y, Z: p.z, Component: component}
+	}
+	forest.Summary.Components = len(componentOf)
+
+	for k, edge := range edges {
+		length := metric.Length(edge.distance)
+		forest.Edges[k] = ForestEdge{From: edge.i, To: edge.j, Length: length, Distance: edge.distance}
+		forest.Summary.TotalWireLength += length
+	}
+
+	return forest
+}
+
+func writeForestJSON(w io.Writer, forest Forest) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(forest)
+}
+
+// writeForestDOT writes the forest as a Graphviz graph, one colour per
+// circuit. Node positions are the x and y coordinates, for neato -n.
+func writeForestDOT(out io.Writer, forest Forest) error {
+	w := bufio.NewWriter(out)
+	fmt.Fprintln(w, "graph circuits {")
+	for _, line := range summaryLines(forest.Summary) {
+		fmt.Fprintf(w, "  // %s\n", line)
+	}
+	fmt.Fprintln(w, "  node [shape=circle, style=filled, fontsize=8];")
+
+	for _, node := range forest.Nodes {
+		// Spread the hues around the colour wheel using the golden ratio
+		hue := math.Mod(float64(node.Component)*0.618033988749895, 1)
+		fmt.Fprintf(w, "  n%d [label=\"%d,%d,%d\", pos=\"%d,%d\", fillcolor=\"%.3f 0.6 0.95\"];\n",
+			node.ID, node.X, node.Y, node.Z, node.X, node.Y, hue)
+	}
+
+	for _, edge := range forest.Edges {
+		fmt.Fprintf(w, "  n%d -- n%d [label=\"%.1f\"];\n", edge.From, edge.To, edge.Length)
+	}
+
+	fmt.Fprintln(w, "}")
+	return w.Flush()
+}
+
+func writeForest(w io.Writer, format string, forest Forest) error {
+	if format == "dot" {
+		return writeForestDOT(w, forest)
+	}
+	return writeForestJSON(w, forest)
+}
+
+func summaryLines(summary ForestSummary) []string {
+	sizes := make([]int, 0, len(summary.Sizes))
+	for size := range summary.Sizes {
+		sizes = append(sizes, size)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(sizes)))
+
+	distribution := ""
+	for k, size := range sizes {
+		if k > 0 {
+			distribution += ", "
+		}
+		distribution += fmt.Sprintf("%d x %d", summary.Sizes[size], size)
+	}
+
+	return []string{
+		fmt.Sprintf("components: %d", summary.Components),
+		fmt.Sprintf("sizes: %s", distribution),
+		fmt.Sprintf("total wire length: %.3f", summary.TotalWireLength),
+	}
+}
+
+// exportCircuits writes the circuits for one part of a file as DOT or JSON
+func exportCircuits(filename, format, outFile string, part, connections int, opts Options) error {
+	if format != "dot" && format != "json" {
+		return fmt.Errorf("unknown export format %q, expected dot or json", format)
+	}
+
+	points := parsePoints(utilities.LoadInput(filename))
+	if len(points) == 0 {
+		return fmt.Errorf("%s: no junction boxes found", filename)
+	}
+
+	var edges []Edge
+	switch part {
+	case 1:
+		_, edges = connectClosest(points, connections, opts)
+	case 2:
+		edges = spanningTree(points, opts)
+	default:
+		return fmt.Errorf("unknown part %d", part)
+	}
+	forest := buildForest(points, edges, opts.Metric)
+
+	if outFile == "" {
+		return writeForest(os.Stdout, format, forest)
+	}
+
+	f, err := os.Create(outFile)
+	if err != nil {
+		return err
+	}
+	err = writeForest(f, format, forest)
+	// A write can fail without an error until the file is closed
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Wrote %s\n", outFile)
+	for _, line := range summaryLines(forest.Summary) {
+		fmt.Printf("  %s\n", line)
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stephen-condon/advent-of-code-2025/utilities"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+// checkGolden compares got with testdata/name, rewriting it with -update
+func checkGolden(t *testing.T, name, got string) {
+	t.Helper()
+	path := filepath.Join("testdata", name)
+	if *update {
+		if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != string(want) {
+		t.Errorf("%s differs from the golden file:\n%s", name, got)
+	}
+}
+
+func exampleForest(t *testing.T, part int) Forest {
+	t.Helper()
+	points := parsePoints(utilities.LoadInput("example.txt"))
+	if part == 1 {
+		_, edges := connectClosest(points, 10, Options{})
+		return buildForest(points, edges, Metric{})
+	}
+	return buildForest(points, spanningTree(points, Options{}), Metric{})
+}
+
+func TestBuildForestExample(t *testing.T) {
+	forest := exampleForest(t, 1)
+
+	// Ten connections with one redundant join nine pairs
+	if len(forest.Nodes) != 20 || len(forest.Edges) != 9 {
+		t.Fatalf("%d nodes and %d edges, want 20 and 9", len(forest.Nodes), len(forest.Edges))
+	}
+
+	want := ForestSummary{Components: 11, Sizes: map[int]int{5: 1, 4: 1, 2: 2, 1: 7}}
+	total := 0.0
+	for _, edge := range forest.Edges {
+		total += edge.Length
+	}
+	want.TotalWireLength = total
+	if !reflect.DeepEqual(forest.Summary, want) {
+		t.Errorf("summary = %+v, want %+v", forest.Summary, want)
+	}
+
+	// Components are numbered by their lowest box, so box 0 is in
+	// component 0 and each new number first appears in box order
+	next := 0
+	for _, node := range forest.Nodes {
+		if node.Component > next {
+			t.Fatalf("box %d is in component %d before component %d was seen", node.ID, node.Component, next)
+		}
+		if node.Component == next {
+			next++
+		}
+	}
+
+	// Every edge joins boxes in the same component
+	for _, edge := range forest.Edges {
+		if forest.Nodes[edge.From].Component != forest.Nodes[edge.To].Component {
+			t.Errorf("edge %d-%d crosses components", edge.From, edge.To)
+		}
+	}
+}
+
+func TestSummaryLinesExample(t *testing.T) {
+	want := []string{
+		"components: 11",
+		"sizes: 1 x 5, 1 x 4, 2 x 2, 7 x 1",
+		"total wire length: 3028.537",
+	}
+	if got := summaryLines(exampleForest(t, 1).Summary); !reflect.DeepEqual(got, want) {
+		t.Errorf("summary lines = %q, want %q", got, want)
+	}
+}
+
+func TestWriteForestDOTExample(t *testing.T) {
+	var b strings.Builder
+	if err := writeForestDOT(&b, exampleForest(t, 1)); err != nil {
+		t.Fatal(err)
+	}
+	checkGolden(t, "example-part1.dot", b.String())
+}
+
+func TestWriteForestJSONExample(t *testing.T) {
+	var b strings.Builder
+	if err := writeForestJSON(&b, exampleForest(t, 2)); err != nil {
+		t.Fatal(err)
+	}
+	checkGolden(t, "example-part2.json", b.String())
+}
+
+func TestExportCircuitsReportsWriteErrors(t *testing.T) {
+	if _, err := os.Stat("/dev/full"); err != nil {
+		t.Skip("no /dev/full")
+	}
+	for _, format := range []string{"dot", "json"} {
+		if err := exportCircuits("example.txt", format, "/dev/full", 1, 10, Options{}); err == nil {
+			t.Errorf("%s: exporting to a full device reported success", format)
+		}
+	}
+}
@@ -0,0 +1,316 @@
+package main
+
+import (
+	"container/heap"
+	"math"
+	"sort"
+)
+
+// KDTree indexes the junction boxes by position so nearest-neighbour
+// questions don't have to look at every pair
+type KDTree struct {
+	points []Point
+	metric Metric
+	nodes  []kdNode
+	root   int
+}
+
+type kdNode struct {
+	point       int // index into points
+	axis        int // 0, 1 or 2 for x, y or z
+	left, right int // child node indices, -1 if absent
+	lo, hi      [3]int
+	label       int // scratch used by Borůvka: component of every point below, or -1
+}
+
+func coord(p Point, axis int) int {
+	switch axis {
+	case 0:
+		return p.x
+	case 1:
+		return p.y
+	}
+	return p.z
+}
+
+func NewKDTree(points []Point, metric Metric) *KDTree {
+	t := &KDTree{points: points, metric: metric, nodes: make([]kdNode, 0, len(points))}
+	indices := make([]int, len(points))
+	for i := range indices {
+		indices[i] = i
+	}
+	t.root = t.build(indices, 0)
+	return t
+}
+
+func (t *KDTree) build(indices []int, depth int) int {
+	if len(indices) == 0 {
+		return -1
+	}
+
+	axis := depth % 3
+	sort.Slice(indices, func(a, b int) bool {
+		return coord(t.points[indices[a]], axis) < coord(t.points[indices[b]], axis)
+	})
+	mid := len(indices) / 2
+
+	node := kdNode{point: indices[mid], axis: axis}
+	for a := 0; a < 3; a++ {
+		node.lo[a], node.hi[a] = math.MaxInt, math.MinInt
+	}
+	for _, i := range indices {
+		for a := 0; a < 3; a++ {
+			c := coord(t.points[i], a)
+			node.lo[a] = min(node.lo[a], c)
+			node.hi[a] = max(node.hi[a], c)
+		}
+	}
+
+	id := len(t.nodes)
+	t.nodes = append(t.nodes, node)
+	left := t.build(indices[:mid], depth+1)
+	right := t.build(indices[mid+1:], depth+1)
+	t.nodes[id].left, t.nodes[id].right = left, right
+	return id
+}
+
+// minDistance is the smallest distance from p to the node's box
+func (n *kdNode) minDistance(p Point, metric Metric) int64 {
+	var gaps [3]int64
+	for a := 0; a < 3; a++ {
+		c := coord(p, a)
+		if c < n.lo[a] {
+			gaps[a] = int64(n.lo[a] - c)
+		} else if c > n.hi[a] {
+			gaps[a] = int64(c - n.hi[a])
+		}
+	}
+	return metric.combine(gaps[0], gaps[1], gaps[2])
+}
+
+// maxDistance is the largest distance from p to the node's box
+func (n *kdNode) maxDistance(p Point, metric Metric) int64 {
+	var gaps [3]int64
+	for a := 0; a < 3; a++ {
+		c := coord(p, a)
+		gaps[a] = int64(max(c-n.lo[a], n.hi[a]-c))
+	}
+	return metric.combine(gaps[0], gaps[1], gaps[2])
+}
+
+// neighbour is a candidate point with its distance from the query
+type neighbour struct {
+	j int
+	d int64
+}
+
+// before orders neighbours of one point by distance, then index
+func (a neighbour) before(b neighbour) bool {
+	return a.d < b.d || (a.d == b.d && a.j < b.j)
+}
+
+// nextNeighbour finds the neighbour of point i that comes straight after
+// prev in (distance, index) order. Pass neighbour{j: -1, d: -1} for the
+// nearest neighbour.
+func (t *KDTree) nextNeighbour(i int, prev neighbour) (neighbour, bool) {
+	p := t.points[i]
+	best := neighbour{j: -1}
+
+	var search func(id int)
+	search = func(id int) {
+		if id == -1 {
+			return
+		}
+		node := &t.nodes[id]
+		if best.j != -1 && node.minDistance(p, t.metric) > best.d {
+			return
+		}
+		// Everything in here was already handed out
+		if node.maxDistance(p, t.metric) < prev.d {
+			return
+		}
+
+		if node.point != i {
+			candidate := neighbour{j: node.point, d: t.metric.Distance(p, t.points[node.point])}
+			if prev.before(candidate) && (best.j == -1 || candidate.before(best)) {
+				best = candidate
+			}
+		}
+
+		// Visit the side the point falls on first to tighten best sooner
+		first, second := node.left, node.right
+		if coord(p, node.axis) > coord(t.points[node.point], node.axis) {
+			first, second = second, first
+		}
+		search(first)
+		search(second)
+	}
+	search(t.root)
+
+	return best, best.j != -1
+}
+
+// PairStream hands out every pair of points in increasing order of
+// distance, ties broken by (i, j), computing only as many as are asked for.
+// Each point keeps a cursor over its own neighbours; a heap merges them.
+type PairStream struct {
+	tree *KDTree
+	heap pairHeap
+}
+
+type pairEntry struct {
+	owner int
+	next  neighbour
+}
+
+type pairHeap []pairEntry
+
+func (h pairHeap) Len() int { return len(h) }
+func (h pairHeap) Less(a, b int) bool {
+	if h[a].next.d != h[b].next.d {
+		return h[a].next.d < h[b].next.d
+	}
+	loA, hiA := min(h[a].owner, h[a].next.j), max(h[a].owner, h[a].next.j)
+	loB, hiB := min(h[b].owner, h[b].next.j), max(h[b].owner, h[b].next.j)
+	if loA != loB {
+		return loA < loB
+	}
+	if hiA != hiB {
+		return hiA < hiB
+	}
+	return h[a].owner < h[b].owner
+}
+func (h pairHeap) Swap(a, b int) { h[a], h[b] = h[b], h[a] }
+func (h *pairHeap) Push(x any)   { *h = append(*h, x.(pairEntry)) }
+func (h *pairHeap) Pop() any {
+	old := *h
+	entry := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return entry
+}
+
+func NewPairStream(points []Point, metric Metric) *PairStream {
+	s := &PairStream{tree: NewKDTree(points, metric)}
+	for i := range points {
+		if next, ok := s.tree.nextNeighbour(i, neighbour{j: -1, d: -1}); ok {
+			s.heap = append(s.heap, pairEntry{owner: i, next: next})
+		}
+	}
+	heap.Init(&s.heap)
+	return s
+}
+
+// Next returns the next closest pair, or false once every pair is used
+func (s *PairStream) Next() (Edge, bool) {
+	for s.heap.Len() > 0 {
+		entry := s.heap[0]
+		if next, ok := s.tree.nextNeighbour(entry.owner, entry.next); ok {
+			s.heap[0].next = next
+			heap.Fix(&s.heap, 0)
+		} else {
+			heap.Pop(&s.heap)
+		}
+
+		// Each pair shows up once from either end; keep the one owned by
+		// the lower index
+		if entry.owner < entry.next.j {
+			return Edge{entry.owner, entry.next.j, entry.next.d}, true
+		}
+	}
+	return Edge{}, false
+}
+
+// closestPairs returns the k closest pairs in increasing order
+func closestPairs(points []Point, k int, metric Metric) []Edge {
+	stream := NewPairStream(points, metric)
+	edges := make([]Edge, 0, k)
+	for len(edges) < k {
+		edge, ok := stream.Next()
+		if !ok {
+			break
+		}
+		edges = append(edges, edge)
+	}
+	return edges
+}
+
+// minimumSpanningTree builds the spanning tree with Borůvka's algorithm:
+// each round every circuit is joined to its nearest box in another circuit,
+// found through the k-d tree. Ties are broken by (i, j) so the tree is the
+// same one Kruskal's algorithm would build over the full sorted pair list.
+func minimumSpanningTree(points []Point, metric Metric) []Edge {
+	n := len(points)
+	tree := NewKDTree(points, metric)
+	uf := NewUnionFind(n)
+	mst := make([]Edge, 0, max(n-1, 0))
+
+	components := n
+	for components > 1 {
+		// Label each subtree with its component when all of it shares one
+		var label func(id int) int
+		label = func(id int) int {
+			if id == -1 {
+				return -2 // empty, matches anything
+			}
+			node := &tree.nodes[id]
+			node.label = uf.Find(node.point)
+			for _, child := range []int{node.left, node.right} {
+				if l := label(child); l != -2 && l != node.label {
+					node.label = -1
+				}
+			}
+			return node.label
+		}
+		label(tree.root)
+
+		best := make(map[int]Edge)
+
+		for i, p := range points {
+			comp := uf.Find(i)
+			current, found := best[comp]
+
+			var search func(id int)
+			search = func(id int) {
+				if id == -1 {
+					return
+				}
+				node := &tree.nodes[id]
+				if node.label == comp {
+					return
+				}
+				if found && node.minDistance(p, metric) > current.distance {
+					return
+				}
+
+				if uf.Find(node.point) != comp {
+					j := node.point
+					edge := Edge{min(i, j), max(i, j), metric.Distance(p, points[j])}
+					if !found || edgeLess(edge, current) {
+						current, found = edge, true
+					}
+				}
+
+				first, second := node.left, node.right
+				if coord(p, node.axis) > coord(points[node.point], node.axis) {
+					first, second = second, first
+				}
+				search(first)
+				search(second)
+			}
+			search(tree.root)
+
+			if found {
+				best[comp] = current
+			}
+		}
+
+		for _, edge := range best {
+			if uf.Union(edge.i, edge.j) {
+				mst = append(mst, edge)
+				components--
+			}
+		}
+	}
+
+	return mst
+}
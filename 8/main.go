@@ -1,8 +1,10 @@
 package main
 
 import (
+	"flag"
 	"fmt"
-	"math"
+	"os"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -14,9 +16,22 @@ type Point struct {
 	x, y, z int
 }
 
+// Edge joins boxes i < j; distance is exact, from Metric.Distance
 type Edge struct {
 	i, j     int
-	distance float64
+	distance int64
+}
+
+// edgeLess orders edges by distance, breaking ties by (i, j) so that equal
+// length edges are always connected in the same order
+func edgeLess(a, b Edge) bool {
+	if a.distance != b.distance {
+		return a.distance < b.distance
+	}
+	if a.i != b.i {
+		return a.i < b.i
+	}
+	return a.j < b.j
 }
 
 type UnionFind struct {
@@ -74,40 +89,219 @@ func (uf *UnionFind) GetComponentSizes() []int {
 	return sizes
 }
 
+// CountMode decides what part one's connection count counts
+type CountMode int
+
+const (
+	CountConsidered CountMode = iota // every edge tried, even if already connected
+	CountUnions                      // only edges that joined two circuits
+)
+
+func (c CountMode) String() string {
+	if c == CountUnions {
+		return "unions"
+	}
+	return "considered"
+}
+
+func parseCountMode(name string) (CountMode, error) {
+	switch name {
+	case "considered":
+		return CountConsidered, nil
+	case "unions":
+		return CountUnions, nil
+	}
+	return 0, fmt.Errorf("unknown count mode %q, expected considered or unions", name)
+}
+
+// caseConnections is part one's connection count for a case, which comes
+// from the case's params in day.json
+func caseConnections(c utilities.Case) (int, error) {
+	connections, ok := c.Params["connections"]
+	if !ok {
+		return 0, fmt.Errorf("%s: no connections parameter in day.json", c.File)
+	}
+	return connections, nil
+}
+
+// connectionsFor looks up part one's connection count for a file in day.json
+func connectionsFor(filename string) (int, error) {
+	cases, err := utilities.LoadCases(".")
+	if err != nil {
+		return 0, err
+	}
+	connections, err := caseConnections(utilities.FindCase(cases, filename))
+	if err != nil {
+		return 0, fmt.Errorf("%v, pass -connections", err)
+	}
+	return connections, nil
+}
+
+// Options controls how day 8 connects boxes and what it reports
+type Options struct {
+	BruteForce bool // build and sort every pair instead of using the k-d tree
+	Metric     Metric
+	Count      CountMode
+	Score      string // one of scoreNames, "answer" if empty
+	TopN       int    // circuits used by the product, sum and top scores
+}
+
 func main() {
+	bruteForce := flag.Bool("bruteforce", false, "build and sort every pair of boxes instead of using the k-d tree")
+	export := flag.String("export", "", "export the circuits as dot or json instead of solving")
+	exportFile := flag.String("out", "", "file to export to (default stdout)")
+	exportPart := flag.Int("part", 1, "export the circuits after part one's connections (1) or the full spanning tree (2)")
+	inputFile := flag.String("input", "example.txt", "boxes to export or log events for")
+	connections := flag.Int("connections", 0, "connections to make before exporting part one (default the input's configured count)")
+	countName := flag.String("count", "considered", "what part one's connections count: considered edges or successful unions")
+	metricName := flag.String("metric", "euclidean", "distance metric: euclidean, squared, manhattan, chebyshev or weighted")
+	weights := flag.String("weights", "1,1,1", "x,y,z integer weights for the weighted metric")
+	score := flag.String("score", "answer", "score to print: "+strings.Join(scoreNames, ", "))
+	topN := flag.Int("top", 3, "number of largest circuits used by the product, sum and top scores")
+	events := flag.Bool("events", false, "print every connection considered for -input instead of solving")
+	eventLimit := flag.Int("limit", 0, "stop the event log after this many connections (default until one circuit)")
+	bench := utilities.AddBenchFlags(flag.CommandLine)
+	profile := utilities.AddProfileFlags(flag.CommandLine)
+	selection := utilities.AddSelectionFlags(flag.CommandLine)
+	flag.Parse()
+
+	metric, err := parseMetric(*metricName, *weights)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	count, err := parseCountMode(*countName)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	if !slices.Contains(scoreNames, *score) {
+		fmt.Printf("unknown score %q, expected one of %s\n", *score, strings.Join(scoreNames, ", "))
+		os.Exit(1)
+	}
+	if *topN < 1 {
+		fmt.Printf("invalid -top %d, must be at least 1\n", *topN)
+		os.Exit(1)
+	}
+	opts := Options{BruteForce: *bruteForce, Metric: metric, Count: count, Score: *score, TopN: *topN}
+
+	if *export != "" {
+		if *connections == 0 {
+			if *connections, err = connectionsFor(*inputFile); err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+		}
+		if err := exportCircuits(*inputFile, *export, *exportFile, *exportPart, *connections, opts); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if *events {
+		if err := printEvents(*inputFile, *eventLimit, opts); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	cases, err := utilities.LoadCases(".")
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	if bench.Enabled() {
+		if err := bench.Run("day8", cases, func(c utilities.Case) []utilities.BenchPart {
+			return benchParts(c, opts)
+		}); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	// The expected answers are for the puzzle's own rules
+	checkTwo := metric.Kind == Euclidean && opts.Score == "answer"
+	checkOne := checkTwo && count == CountConsidered && opts.TopN == 3
+
+	// Exit non-zero if any part fails, so that run-all and scripts notice
+	failed := false
+
 	fmt.Println("Part One:")
-	executePartOne("example.txt", 10)
-	executePartOne("input.txt", 1000)
+	for _, c := range selection.Cases(cases, 1) {
+		connections, err := caseConnections(c)
+		if err != nil {
+			fmt.Println(err)
+			failed = true
+			continue
+		}
+		answer := profile.Run("day8", c, 1, func() string {
+			return executePartOne(c.File, connections, opts)
+		})
+		if answer == "" || checkOne && !utilities.CheckAnswer(c, 1, answer) {
+			failed = true
+		}
+	}
 
 	fmt.Println("\nPart Two:")
-	executePartTwo("example.txt")
-	executePartTwo("input.txt")
+	for _, c := range selection.Cases(cases, 2) {
+		answer := profile.Run("day8", c, 2, func() string {
+			return executePartTwo(c.File, opts)
+		})
+		if answer == "" || checkTwo && !utilities.CheckAnswer(c, 2, answer) {
+			failed = true
+		}
+	}
+
+	if failed {
+		os.Exit(1)
+	}
 }
 
-func executePartOne(filename string, connections int) {
+func executePartOne(filename string, connections int, opts Options) string {
 	input := utilities.LoadInput(filename)
 	if len(input) == 0 {
 		fmt.Println("No input found")
-		return
+		return ""
 	}
 
-	result := solveJunctionBoxes(input, connections)
-	fmt.Printf("%s: %d\n", filename, result)
+	result := circuitResult(parsePoints(input), connections, opts)
+	fmt.Printf("  Circuit sizes: %v\n", result.Sizes)
+	return printScore(filename, result, opts)
 }
 
-func executePartTwo(filename string) {
+func executePartTwo(filename string, opts Options) string {
 	input := utilities.LoadInput(filename)
 	if len(input) == 0 {
 		fmt.Println("No input found")
-		return
+		return ""
 	}
 
-	result := findUnifyingConnection(input)
-	fmt.Printf("%s: %d\n", filename, result)
+	result := unifyingResult(parsePoints(input), opts)
+	return printScore(filename, result, opts)
 }
 
-func findUnifyingConnection(lines []string) int {
-	// Parse points
+// printScore prints the selected score and returns it, or "" if the result
+// doesn't have one
+func printScore(filename string, result Result, opts Options) string {
+	name := opts.Score
+	if name == "" {
+		name = "answer"
+	}
+
+	score, err := result.Score(name, opts.TopN)
+	if err != nil {
+		fmt.Printf("%s: %v\n", filename, err)
+		return ""
+	}
+	fmt.Printf("%s: %s\n", filename, score)
+	return score
+}
+
+func parsePoints(lines []string) []Point {
 	points := make([]Point, 0, len(lines))
 	for _, line := range lines {
 		line = strings.TrimSpace(line)
@@ -123,98 +317,118 @@ func findUnifyingConnection(lines []string) int {
 		z, _ := strconv.Atoi(parts[2])
 		points = append(points, Point{x, y, z})
 	}
+	return points
+}
 
+// sortedPairEdges builds every pairwise edge and sorts them by distance
+func sortedPairEdges(points []Point, metric Metric) []Edge {
 	n := len(points)
 
 	// Calculate all pairwise distances
 	edges := make([]Edge, 0, n*(n-1)/2)
 	for i := 0; i < n; i++ {
 		for j := i + 1; j < n; j++ {
-			dist := distance(points[i], points[j])
+			dist := metric.Distance(points[i], points[j])
 			edges = append(edges, Edge{i, j, dist})
 		}
 	}
 
-	// Sort edges by distance
-	sort.Slice(edges, func(i, j int) bool {
-		return edges[i].distance < edges[j].distance
+	// Sort edges by distance, then by (i, j)
+	sort.Slice(edges, func(a, b int) bool {
+		return edgeLess(edges[a], edges[b])
 	})
 
+	return edges
+}
+
+// spanningTree returns the edges that join two circuits, in the order
+// Kruskal's algorithm connects them, ending with the one that unifies
+// every box
+func spanningTree(points []Point, opts Options) []Edge {
+	n := len(points)
+
+	// Only the spanning tree edges can ever join two circuits, so with the
+	// k-d tree there's no need to look at any other pair
+	var edges []Edge
+	if opts.BruteForce {
+		edges = sortedPairEdges(points, opts.Metric)
+	} else {
+		edges = minimumSpanningTree(points, opts.Metric)
+		sort.Slice(edges, func(a, b int) bool {
+			return edgeLess(edges[a], edges[b])
+		})
+	}
+
 	// Use Union-Find and connect edges until all are in one circuit
 	uf := NewUnionFind(n)
+	tree := make([]Edge, 0, max(n-1, 0))
 
 	for _, edge := range edges {
+		if len(tree) == n-1 {
+			break
+		}
 		if uf.Union(edge.i, edge.j) {
-			// Check if all nodes are now in one circuit
-			// This happens when we have exactly 1 component
-			sizes := uf.GetComponentSizes()
-			if len(sizes) == 1 {
-				// This connection unified everything
-				return points[edge.i].x * points[edge.j].x
-			}
+			tree = append(tree, edge)
 		}
 	}
 
-	return 0
+	return tree
 }
 
-func solveJunctionBoxes(lines []string, connections int) int {
-	// Parse points
-	points := make([]Point, 0, len(lines))
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if line == "" {
-			continue
-		}
-		parts := strings.Split(line, ",")
-		if len(parts) != 3 {
-			continue
-		}
-		x, _ := strconv.Atoi(parts[0])
-		y, _ := strconv.Atoi(parts[1])
-		z, _ := strconv.Atoi(parts[2])
-		points = append(points, Point{x, y, z})
-	}
-
-	n := len(points)
+func findUnifyingConnection(lines []string, opts Options) int {
+	return unifyingResult(parsePoints(lines), opts).Answer()
+}
 
-	// Calculate all pairwise distances
-	edges := make([]Edge, 0, n*(n-1)/2)
-	for i := 0; i < n; i++ {
-		for j := i + 1; j < n; j++ {
-			dist := distance(points[i], points[j])
-			edges = append(edges, Edge{i, j, dist})
-		}
+// connectClosest connects the closest pairs of boxes, returning the
+// circuits and the connections that joined two of them. With CountUnions
+// only the connections that join two circuits count towards the total.
+func connectClosest(points []Point, connections int, opts Options) (*UnionFind, []Edge) {
+	if opts.Count == CountUnions {
+		return connectUnions(points, connections, opts)
 	}
 
-	// Sort edges by distance
-	sort.Slice(edges, func(i, j int) bool {
-		return edges[i].distance < edges[j].distance
-	})
+	var edges []Edge
+	if opts.BruteForce {
+		edges = sortedPairEdges(points, opts.Metric)
+	} else {
+		edges = closestPairs(points, connections, opts.Metric)
+	}
 
 	// Use Union-Find to connect closest pairs
-	uf := NewUnionFind(n)
+	uf := NewUnionFind(len(points))
+	var joined []Edge
 
 	for i := 0; i < connections && i < len(edges); i++ {
-		uf.Union(edges[i].i, edges[i].j)
+		if uf.Union(edges[i].i, edges[i].j) {
+			joined = append(joined, edges[i])
+		}
 	}
 
-	// Get component sizes
-	sizes := uf.GetComponentSizes()
-	sort.Sort(sort.Reverse(sort.IntSlice(sizes)))
+	return uf, joined
+}
 
-	fmt.Printf("  Circuit sizes: %v\n", sizes)
+// connectUnions keeps connecting the closest boxes until the given number
+// of connections have joined two circuits, or everything is one circuit
+func connectUnions(points []Point, unions int, opts Options) (*UnionFind, []Edge) {
+	uf := NewUnionFind(len(points))
+	var joined []Edge
 
-	// Multiply three largest
-	if len(sizes) < 3 {
-		return 0
+	if unions <= 0 {
+		return uf, joined
+	}
+	for event := range kruskalEvents(points, opts) {
+		if event.Merged {
+			uf.Union(event.Edge.i, event.Edge.j)
+			joined = append(joined, event.Edge)
+		}
+		if len(joined) == unions || event.Components == 1 {
+			break
+		}
 	}
-	return sizes[0] * sizes[1] * sizes[2]
+
+	return uf, joined
 }
 
-func distance(p1, p2 Point) float64 {
-	dx := float64(p1.x - p2.x)
-	dy := float64(p1.y - p2.y)
-	dz := float64(p1.z - p2.z)
-	return math.Sqrt(dx*dx + dy*dy + dz*dz)
+func solveJunctionBoxes(lines []string, connections int, opts Options) int {
+	return circuitResult(parsePoints(lines), connections, opts).Answer()
 }
@@ -0,0 +1,330 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/stephen-condon/advent-of-code-2025/utilities"
+)
+
+func randomPoints(n, spread int, seed int64) []Point {
+	rng := rand.New(rand.NewSource(seed))
+	points := make([]Point, n)
+	for i := range points {
+		points[i] = Point{rng.Intn(spread), rng.Intn(spread), rng.Intn(spread)}
+	}
+	return points
+}
+
+func TestClosestPairsMatchesBruteForce(t *testing.T) {
+	inputs := map[string][]Point{
+		"input.txt": parsePoints(utilities.LoadInput("input.txt")),
+		"random":    randomPoints(300, 1000, 1),
+	}
+
+	for name, points := range inputs {
+		want := sortedPairEdges(points, Metric{})[:1000]
+		got := closestPairs(points, 1000, Metric{})
+		for k := range want {
+			if got[k] != want[k] {
+				t.Fatalf("%s: pair %d is %v, brute force has %v", name, k, got[k], want[k])
+			}
+		}
+	}
+}
+
+func TestSpatialIndexMatchesBruteForce(t *testing.T) {
+	for _, filename := range []string{"example.txt", "input.txt"} {
+		input := utilities.LoadInput(filename)
+		connections, _ := connectionsFor(filename)
+
+		if got, want := solveJunctionBoxes(input, connections, Options{}), solveJunctionBoxes(input, connections, Options{BruteForce: true}); got != want {
+			t.Errorf("%s: solveJunctionBoxes = %d, brute force gives %d", filename, got, want)
+		}
+		if got, want := findUnifyingConnection(input, Options{}), findUnifyingConnection(input, Options{BruteForce: true}); got != want {
+			t.Errorf("%s: findUnifyingConnection = %d, brute force gives %d", filename, got, want)
+		}
+	}
+}
+
+// latticePoints lays boxes out on an evenly spaced cube, so most distances
+// are shared by many pairs
+func latticePoints(side, spacing int) []Point {
+	var points []Point
+	for x := 0; x < side; x++ {
+		for y := 0; y < side; y++ {
+			for z := 0; z < side; z++ {
+				points = append(points, Point{x * spacing, y * spacing, z * spacing})
+			}
+		}
+	}
+	return points
+}
+
+func TestTiedDistancesAreOrderedByIndex(t *testing.T) {
+	points := latticePoints(4, 10)
+	edges := sortedPairEdges(points, Metric{})
+
+	for k := 1; k < len(edges); k++ {
+		if !edgeLess(edges[k-1], edges[k]) {
+			t.Fatalf("edges %v and %v are out of (distance, i, j) order", edges[k-1], edges[k])
+		}
+	}
+
+	if got := closestPairs(points, len(edges), Metric{}); len(got) != len(edges) {
+		t.Fatalf("closestPairs returned %d pairs, want %d", len(got), len(edges))
+	} else {
+		for k := range edges {
+			if got[k] != edges[k] {
+				t.Fatalf("pair %d is %v with the k-d tree, %v with brute force", k, got[k], edges[k])
+			}
+		}
+	}
+}
+
+func TestTiedDistancesUnionOrder(t *testing.T) {
+	// Four boxes 10 apart on a line, listed out of order:
+	//
+	//	x:     0   10   20   30
+	//	box:   1    3    0    2
+	//
+	// The three gaps of 10 tie, so they join in (i, j) order: (0,2), (0,3)
+	// and then (1,3), followed by the gaps of 20, (0,1) and (2,3), and the
+	// gap of 30, (1,2)
+	points := []Point{{20, 0, 0}, {0, 0, 0}, {30, 0, 0}, {10, 0, 0}}
+	order := [][2]int{{0, 2}, {0, 3}, {1, 3}, {0, 1}, {2, 3}, {1, 2}}
+
+	pairs := func(edges []Edge) [][2]int {
+		got := make([][2]int, len(edges))
+		for k, edge := range edges {
+			got[k] = [2]int{edge.i, edge.j}
+		}
+		return got
+	}
+
+	if got := pairs(sortedPairEdges(points, Metric{})); !reflect.DeepEqual(got, order) {
+		t.Errorf("sortedPairEdges order %v, want %v", got, order)
+	}
+	if got := pairs(closestPairs(points, len(order), Metric{})); !reflect.DeepEqual(got, order) {
+		t.Errorf("closestPairs order %v, want %v", got, order)
+	}
+
+	for _, opts := range []Options{{}, {BruteForce: true}} {
+		// Two connections join box 0 to 2 and then to 3, leaving 1 alone
+		_, joined := connectClosest(points, 2, opts)
+		if got := pairs(joined); !reflect.DeepEqual(got, order[:2]) {
+			t.Errorf("%+v: two connections joined %v, want %v", opts, got, order[:2])
+		}
+
+		if got := pairs(spanningTree(points, opts)); !reflect.DeepEqual(got, order[:3]) {
+			t.Errorf("%+v: spanning tree %v, want %v", opts, got, order[:3])
+		}
+	}
+}
+
+func TestTiedDistancesFollowShuffledIndices(t *testing.T) {
+	points := latticePoints(3, 10)
+	original := make(map[[2]int]int64)
+	for _, edge := range sortedPairEdges(points, Metric{}) {
+		original[[2]int{edge.i, edge.j}] = edge.distance
+	}
+
+	for seed := int64(1); seed <= 5; seed++ {
+		perm := rand.New(rand.NewSource(seed)).Perm(len(points))
+		shuffled := make([]Point, len(points))
+		for k, i := range perm {
+			shuffled[k] = points[i]
+		}
+
+		// Whatever order the boxes come in, ties are broken by their
+		// position in the input, by the k-d tree as well as brute force
+		want := sortedPairEdges(shuffled, Metric{})
+		got := closestPairs(shuffled, len(want), Metric{})
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("seed %d: k-d tree and brute force order the tied pairs differently", seed)
+		}
+		for k := 1; k < len(got); k++ {
+			if !edgeLess(got[k-1], got[k]) {
+				t.Fatalf("seed %d: edges %v and %v are out of (distance, i, j) order", seed, got[k-1], got[k])
+			}
+		}
+
+		// Mapped back to the original boxes, they are the same pairs
+		for _, edge := range got {
+			i, j := perm[edge.i], perm[edge.j]
+			if distance, ok := original[[2]int{min(i, j), max(i, j)}]; !ok || distance != edge.distance {
+				t.Fatalf("seed %d: edge %v maps back to boxes %d and %d, which aren't %d apart", seed, edge, i, j, edge.distance)
+			}
+		}
+
+		tree := spanningTree(shuffled, Options{})
+		if bruteTree := spanningTree(shuffled, Options{BruteForce: true}); !reflect.DeepEqual(tree, bruteTree) {
+			t.Fatalf("seed %d: spanning trees differ, %v with the k-d tree, %v with brute force", seed, tree, bruteTree)
+		}
+		for _, connections := range []int{20, 40, 60} {
+			if a, b := solveJunctionBoxes(pointLines(shuffled), connections, Options{}), solveJunctionBoxes(pointLines(shuffled), connections, Options{BruteForce: true}); a != b {
+				t.Errorf("seed %d, %d connections: k-d tree gives %d, brute force gives %d", seed, connections, a, b)
+			}
+		}
+	}
+}
+
+func pointLines(points []Point) []string {
+	lines := make([]string, len(points))
+	for i, p := range points {
+		lines[i] = fmt.Sprintf("%d,%d,%d", p.x, p.y, p.z)
+	}
+	return lines
+}
+
+func TestMetricsOnExample(t *testing.T) {
+	input := utilities.LoadInput("example.txt")
+
+	cases := []struct {
+		metric   Metric
+		partOne  int
+		unifying int
+	}{
+		{Metric{Kind: Euclidean}, 40, 25272},
+		{Metric{Kind: SquaredEuclidean}, 40, 25272},
+		{Metric{Kind: Manhattan}, 36, 452020},
+		{Metric{Kind: Chebyshev}, 60, 794430},
+		{Metric{Kind: WeightedEuclidean, Weights: [3]int64{1, 2, 3}}, 60, 127872},
+	}
+
+	for _, c := range cases {
+		for _, bruteForce := range []bool{false, true} {
+			opts := Options{BruteForce: bruteForce, Metric: c.metric}
+			if got := solveJunctionBoxes(input, 10, opts); got != c.partOne {
+				t.Errorf("%v (brute force %v): solveJunctionBoxes = %d, want %d", c.metric, bruteForce, got, c.partOne)
+			}
+			if got := findUnifyingConnection(input, opts); got != c.unifying {
+				t.Errorf("%v (brute force %v): findUnifyingConnection = %d, want %d", c.metric, bruteForce, got, c.unifying)
+			}
+		}
+	}
+}
+
+func TestWeightedMetricWithUnitWeightsIsEuclidean(t *testing.T) {
+	points := parsePoints(utilities.LoadInput("example.txt"))
+	weighted := Metric{Kind: WeightedEuclidean, Weights: [3]int64{1, 1, 1}}
+
+	want := sortedPairEdges(points, Metric{})
+	got := sortedPairEdges(points, weighted)
+	for k := range want {
+		if got[k] != want[k] {
+			t.Fatalf("pair %d is %v weighted, %v euclidean", k, got[k], want[k])
+		}
+	}
+}
+
+func TestEventsFollowKruskal(t *testing.T) {
+	points := parsePoints(utilities.LoadInput("input.txt"))
+
+	for _, bruteForce := range []bool{false, true} {
+		opts := Options{BruteForce: bruteForce}
+		tree := spanningTree(points, opts)
+		_, joined := connectClosest(points, 1000, opts)
+
+		var merged []Edge
+		for event := range kruskalEvents(points, opts) {
+			if event.Merged {
+				merged = append(merged, event.Edge)
+			}
+			if event.Step == 1000 && len(merged) != len(joined) {
+				t.Errorf("brute force %v: %d merges in 1000 events, connectClosest made %d", bruteForce, len(merged), len(joined))
+			}
+			if event.Components != len(points)-len(merged) {
+				t.Fatalf("brute force %v: event %d reports %d circuits after %d merges", bruteForce, event.Step, event.Components, len(merged))
+			}
+			if event.Components == 1 {
+				break
+			}
+		}
+
+		if len(merged) != len(tree) {
+			t.Fatalf("brute force %v: %d merges, spanning tree has %d edges", bruteForce, len(merged), len(tree))
+		}
+		for k := range tree {
+			if merged[k] != tree[k] {
+				t.Fatalf("brute force %v: merge %d is %v, spanning tree has %v", bruteForce, k, merged[k], tree[k])
+			}
+		}
+	}
+}
+
+func TestEventsFindHalfCircuit(t *testing.T) {
+	points := parsePoints(utilities.LoadInput("example.txt"))
+
+	step := 0
+	for event := range kruskalEvents(points, Options{}) {
+		if event.Largest*2 >= len(points) {
+			step = event.Step
+			break
+		}
+	}
+
+	// The example's largest circuit reaches 10 of its 20 boxes on the 15th
+	// connection
+	if step != 15 {
+		t.Errorf("largest circuit reaches half the boxes after %d connections, want 15", step)
+	}
+}
+
+func TestCountModesOnExample(t *testing.T) {
+	input := utilities.LoadInput("example.txt")
+
+	// Ten edges considered include one redundant edge (the 4th) so leave
+	// eleven circuits, while ten successful unions leave ten
+	cases := []struct {
+		count  CountMode
+		sizes  []int
+		answer int
+	}{
+		{CountConsidered, []int{5, 4, 2, 2, 1, 1, 1, 1, 1, 1, 1}, 40},
+		{CountUnions, []int{5, 5, 2, 2, 1, 1, 1, 1, 1, 1}, 50},
+	}
+
+	for _, c := range cases {
+		for _, bruteForce := range []bool{false, true} {
+			opts := Options{BruteForce: bruteForce, Count: c.count}
+			result := circuitResult(parsePoints(input), 10, opts)
+			if fmt.Sprint(result.Sizes) != fmt.Sprint(c.sizes) {
+				t.Errorf("%v (brute force %v): sizes %v, want %v", c.count, bruteForce, result.Sizes, c.sizes)
+			}
+			if got := result.Answer(); got != c.answer {
+				t.Errorf("%v (brute force %v): answer %d, want %d", c.count, bruteForce, got, c.answer)
+			}
+		}
+	}
+}
+
+func TestCountUnionsStopsAtOneCircuit(t *testing.T) {
+	points := parsePoints(utilities.LoadInput("example.txt"))
+
+	uf, joined := connectClosest(points, 100, Options{Count: CountUnions})
+	if len(joined) != len(points)-1 {
+		t.Errorf("made %d unions, want %d", len(joined), len(points)-1)
+	}
+	if sizes := uf.GetComponentSizes(); len(sizes) != 1 {
+		t.Errorf("left %d circuits, want 1", len(sizes))
+	}
+}
+
+func TestConnectionsComeFromDayJSON(t *testing.T) {
+	for filename, want := range map[string]int{"example.txt": 10, "input.txt": 1000} {
+		if got, err := connectionsFor(filename); err != nil || got != want {
+			t.Errorf("connectionsFor(%s) = %d, %v, want %d", filename, got, err, want)
+		}
+	}
+
+	// A file day.json doesn't configure has no count to fall back on
+	if _, err := connectionsFor("example2.txt"); err == nil {
+		t.Error("expected an error for a file day.json doesn't list")
+	}
+	if _, err := benchParts(utilities.Case{File: "example2.txt"}, Options{})[0].Parse(nil); err == nil {
+		t.Error("expected benchmarking part one to need a connections parameter")
+	}
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+type MetricKind int
+
+const (
+	Euclidean MetricKind = iota
+	SquaredEuclidean
+	Manhattan
+	Chebyshev
+	WeightedEuclidean
+)
+
+// Metric measures the wire needed between two boxes. Every metric is built
+// from the per-axis gaps and grows with each of them, which is what lets
+// the k-d tree bound a whole box of points at once. Distances are kept as
+// exact integers that order pairs the same way the real length does.
+type Metric struct {
+	Kind    MetricKind
+	Weights [3]int64 // per-axis weights for WeightedEuclidean
+}
+
+// combine turns absolute per-axis gaps into the metric's integer distance
+func (m Metric) combine(dx, dy, dz int64) int64 {
+	switch m.Kind {
+	case Manhattan:
+		return dx + dy + dz
+	case Chebyshev:
+		return max(dx, dy, dz)
+	case WeightedEuclidean:
+		return m.Weights[0]*dx*dx + m.Weights[1]*dy*dy + m.Weights[2]*dz*dz
+	}
+	// Euclidean is compared squared, which keeps it exact without
+	// changing which pairs are closest
+	return dx*dx + dy*dy + dz*dz
+}
+
+func (m Metric) Distance(p1, p2 Point) int64 {
+	return m.combine(abs(int64(p1.x-p2.x)), abs(int64(p1.y-p2.y)), abs(int64(p1.z-p2.z)))
+}
+
+// Length converts a distance from Distance into the length of wire
+func (m Metric) Length(distance int64) float64 {
+	if m.Kind == Euclidean || m.Kind == WeightedEuclidean {
+		return math.Sqrt(float64(distance))
+	}
+	return float64(distance)
+}
+
+func (m Metric) String() string {
+	switch m.Kind {
+	case SquaredEuclidean:
+		return "squared"
+	case Manhattan:
+		return "manhattan"
+	case Chebyshev:
+		return "chebyshev"
+	case WeightedEuclidean:
+		return fmt.Sprintf("weighted(%d,%d,%d)", m.Weights[0], m.Weights[1], m.Weights[2])
+	}
+	return "euclidean"
+}
+
+// parseMetric reads a metric name, with "x,y,z" integer weights for the
+// weighted metric
+func parseMetric(name, weights string) (Metric, error) {
+	switch name {
+	case "euclidean":
+		return Metric{Kind: Euclidean}, nil
+	case "squared":
+		return Metric{Kind: SquaredEuclidean}, nil
+	case "manhattan":
+		return Metric{Kind: Manhattan}, nil
+	case "chebyshev":
+		return Metric{Kind: Chebyshev}, nil
+	case "weighted":
+		parts := strings.Split(weights, ",")
+		if len(parts) != 3 {
+			return Metric{}, fmt.Errorf("weighted metric needs x,y,z weights, got %q", weights)
+		}
+		m := Metric{Kind: WeightedEuclidean}
+		for a, part := range parts {
+			w, err := strconv.ParseInt(strings.TrimSpace(part), 10, 64)
+			if err != nil || w < 0 {
+				return Metric{}, fmt.Errorf("invalid weight %q", part)
+			}
+			m.Weights[a] = w
+		}
+		return m, nil
+	}
+	return Metric{}, fmt.Errorf("unknown metric %q, expected euclidean, squared, manhattan, chebyshev or weighted", name)
+}
+
+func abs(v int64) int64 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Result holds what is known about the circuits after one part, so any of
+// the scores can be derived from it
+type Result struct {
+	Part       int
+	Sizes      []int // circuit sizes, largest first
+	Components int
+	Unifying   *Edge // the connection that joined every box, part two only
+	From, To   Point // the boxes at either end of Unifying
+}
+
+func newResult(part int, uf *UnionFind) Result {
+	sizes := uf.GetComponentSizes()
+	sort.Sort(sort.Reverse(sort.IntSlice(sizes)))
+	return Result{Part: part, Sizes: sizes, Components: len(sizes)}
+}
+
+// circuitResult makes part one's connections and reports the circuits
+func circuitResult(points []Point, connections int, opts Options) Result {
+	uf, _ := connectClosest(points, connections, opts)
+	return newResult(1, uf)
+}
+
+// unifyingResult connects boxes until they form a single circuit
+func unifyingResult(points []Point, opts Options) Result {
+	tree := spanningTree(points, opts)
+
+	uf := NewUnionFind(len(points))
+	for _, edge := range tree {
+		uf.Union(edge.i, edge.j)
+	}
+	result := newResult(2, uf)
+
+	if len(points) >= 2 && len(tree) == len(points)-1 {
+		// The last connection unified everything
+		last := tree[len(tree)-1]
+		result.Unifying = &last
+		result.From, result.To = points[last.i], points[last.j]
+	}
+
+	return result
+}
+
+// Top returns the n largest circuit sizes
+func (r Result) Top(n int) []int {
+	return r.Sizes[:min(n, len(r.Sizes))]
+}
+
+// Product multiplies the n largest circuit sizes, or is 0 if there are
+// fewer than n circuits
+func (r Result) Product(n int) int {
+	if len(r.Sizes) < n {
+		return 0
+	}
+	product := 1
+	for _, size := range r.Top(n) {
+		product *= size
+	}
+	return product
+}
+
+// Sum adds up the n largest circuit sizes
+func (r Result) Sum(n int) int {
+	sum := 0
+	for _, size := range r.Top(n) {
+		sum += size
+	}
+	return sum
+}
+
+// Answer is the puzzle's answer: the product of the three largest circuits
+// for part one, and the product of the unifying boxes' x coordinates for
+// part two
+func (r Result) Answer() int {
+	if r.Part == 2 {
+		if r.Unifying == nil {
+			return 0
+		}
+		return r.From.x * r.To.x
+	}
+	return r.Product(3)
+}
+
+var scoreNames = []string{"answer", "product", "sum", "components", "top", "edge"}
+
+// Score formats the named score, using the n largest circuits where the
+// score needs a count
+func (r Result) Score(name string, n int) (string, error) {
+	switch name {
+	case "answer":
+		return strconv.Itoa(r.Answer()), nil
+	case "product":
+		return strconv.Itoa(r.Product(n)), nil
+	case "sum":
+		return strconv.Itoa(r.Sum(n)), nil
+	case "components":
+		return strconv.Itoa(r.Components), nil
+	case "top":
+		return fmt.Sprint(r.Top(n)), nil
+	case "edge":
+		if r.Unifying == nil {
+			return "", fmt.Errorf("no unifying connection in part %d", r.Part)
+		}
+		return fmt.Sprintf("%d,%d,%d - %d,%d,%d", r.From.x, r.From.y, r.From.z, r.To.x, r.To.y, r.To.z), nil
+	}
+	return "", fmt.Errorf("unknown score %q, expected one of %s", name, strings.Join(scoreNames, ", "))
+}
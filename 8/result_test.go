@@ -0,0 +1,70 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stephen-condon/advent-of-code-2025/utilities"
+)
+
+func TestScoresOnExample(t *testing.T) {
+	points := parsePoints(utilities.LoadInput("example.txt"))
+	results := map[int]Result{
+		1: circuitResult(points, 10, Options{}),
+		2: unifyingResult(points, Options{}),
+	}
+
+	tests := []struct {
+		part  int
+		score string
+		n     int
+		want  string
+	}{
+		{1, "answer", 3, "40"},
+		{1, "product", 3, "40"},
+		{1, "product", 1, "5"},
+		{1, "product", 11, "80"},
+		{1, "product", 12, "0"},
+		{1, "sum", 3, "11"},
+		{1, "sum", 100, "20"},
+		{1, "components", 3, "11"},
+		{1, "top", 4, "[5 4 2 2]"},
+		{1, "top", 1, "[5]"},
+		{1, "top", 20, "[5 4 2 2 1 1 1 1 1 1 1]"},
+		{2, "answer", 3, "25272"},
+		{2, "product", 1, "20"},
+		{2, "product", 2, "0"},
+		{2, "sum", 3, "20"},
+		{2, "components", 3, "1"},
+		{2, "top", 3, "[20]"},
+		{2, "edge", 3, "216,146,977 - 117,168,530"},
+	}
+
+	for _, tt := range tests {
+		got, err := results[tt.part].Score(tt.score, tt.n)
+		if err != nil {
+			t.Errorf("part %d %s %d: %v", tt.part, tt.score, tt.n, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("part %d %s %d = %s, want %s", tt.part, tt.score, tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestScoreErrors(t *testing.T) {
+	result := circuitResult(parsePoints(utilities.LoadInput("example.txt")), 10, Options{})
+
+	if _, err := result.Score("edge", 3); err == nil || err.Error() != "no unifying connection in part 1" {
+		t.Errorf("edge in part one: error = %v", err)
+	}
+	if _, err := result.Score("median", 3); err == nil {
+		t.Error("expected an error for an unknown score")
+	}
+}
+
+func TestUnifyingResultNeedsTwoBoxes(t *testing.T) {
+	result := unifyingResult([]Point{{1, 2, 3}}, Options{})
+	if result.Unifying != nil || result.Answer() != 0 {
+		t.Errorf("one box: unifying %v, answer %d, want none and 0", result.Unifying, result.Answer())
+	}
+}
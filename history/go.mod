@@ -0,0 +1,7 @@
+module history
+
+go 1.25.0
+
+require github.com/stephen-condon/advent-of-code-2025/utilities v0.0.0
+
+replace github.com/stephen-condon/advent-of-code-2025/utilities => ../utilities
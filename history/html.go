@@ -0,0 +1,96 @@
+package main
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+	"time"
+)
+
+const chartWidth, chartHeight = 600, 120
+
+var reportTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Benchmark history</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+h2 { font-size: 1em; margin-bottom: 0.2em; }
+svg { background: #f6f8fa; }
+polyline { fill: none; stroke: #0366d6; stroke-width: 2; }
+circle { fill: #0366d6; }
+.summary { color: #586069; font-size: 0.9em; }
+</style>
+</head>
+<body>
+<h1>Benchmark history</h1>
+{{range .}}
+<h2>{{.Name}}</h2>
+<div class="summary">{{.Summary}}</div>
+<svg width="{{.Width}}" height="{{.Height}}" viewBox="0 0 {{.Width}} {{.Height}}">
+<polyline points="{{.Line}}"/>
+{{range .Dots}}<circle cx="{{.X}}" cy="{{.Y}}" r="3"><title>{{.Label}}</title></circle>
+{{end}}</svg>
+{{end}}
+</body>
+</html>
+`))
+
+type chartDot struct {
+	X, Y  int
+	Label string
+}
+
+type chart struct {
+	Name          string
+	Summary       string
+	Width, Height int
+	Line          string
+	Dots          []chartDot
+}
+
+// newChart plots a series' total times left to right in run order, with
+// the slowest run at the top
+func newChart(s Series) chart {
+	values := s.values()
+	hi := values[0]
+	for _, v := range values {
+		hi = max(hi, v)
+	}
+
+	c := chart{Name: s.Name(), Width: chartWidth, Height: chartHeight}
+	const pad = 8
+	var line []string
+	for k, p := range s.Points {
+		x := pad
+		if len(values) > 1 {
+			x += k * (chartWidth - 2*pad) / (len(values) - 1)
+		}
+		y := chartHeight - pad
+		if hi > 0 {
+			y -= int(p.TotalNs * (chartHeight - 2*pad) / hi)
+		}
+
+		line = append(line, fmt.Sprintf("%d,%d", x, y))
+		c.Dots = append(c.Dots, chartDot{X: x, Y: y, Label: fmt.Sprintf("%s %s: %v on %s",
+			p.Time.Format(time.DateTime), p.Commit, time.Duration(p.TotalNs), p.Machine)})
+	}
+	c.Line = strings.Join(line, " ")
+
+	last := s.Points[len(s.Points)-1]
+	c.Summary = fmt.Sprintf("%d runs, latest %v at %s", len(values), time.Duration(last.TotalNs), last.Commit)
+	if k, change := s.worstStep(); k >= 0 {
+		c.Summary += fmt.Sprintf(", worst step %+.1f%% at %s", change*100, s.Points[k].Commit)
+	}
+	return c
+}
+
+func writeHTML(w io.Writer, series []Series) error {
+	charts := make([]chart, len(series))
+	for k, s := range series {
+		charts[k] = newChart(s)
+	}
+	return reportTemplate.Execute(w, charts)
+}
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/stephen-condon/advent-of-code-2025/utilities"
+)
+
+// Point is one run's total time for a part
+type Point struct {
+	Time    time.Time
+	Commit  string
+	Machine string
+	TotalNs int64
+}
+
+// Series is the history of one part of one day on one input
+type Series struct {
+	Day, File, Part string
+	Points          []Point
+}
+
+func (s Series) Name() string {
+	return fmt.Sprintf("%s %s %s", s.Day, s.File, s.Part)
+}
+
+func main() {
+	historyFile := flag.String("file", utilities.DefaultHistoryFile(), "history file written by each day's -bench -history")
+	day := flag.String("day", "", "only show this day, e.g. day8")
+	machine := flag.String("machine", "", "only show runs on machines whose description contains this")
+	htmlFile := flag.String("html", "", "write an HTML report to this file instead of printing sparklines")
+	flag.Parse()
+
+	entries, err := utilities.ReadHistory(*historyFile)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	series := buildSeries(entries, *day, *machine)
+	if len(series) == 0 {
+		fmt.Println("No matching runs found")
+		return
+	}
+
+	if *htmlFile == "" {
+		writeSparklines(os.Stdout, series)
+		return
+	}
+
+	f, err := os.Create(*htmlFile)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	if err := writeHTML(f, series); err != nil {
+		f.Close()
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	if err := f.Close(); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	fmt.Printf("Wrote %s\n", *htmlFile)
+}
+
+// buildSeries groups the runs by day, input and part, oldest run first
+func buildSeries(entries []utilities.HistoryEntry, day, machine string) []Series {
+	byName := make(map[string]*Series)
+	for _, entry := range entries {
+		if day != "" && entry.Day != day {
+			continue
+		}
+		if machine != "" && !strings.Contains(entry.Machine, machine) {
+			continue
+		}
+
+		for _, result := range entry.Results {
+			s := Series{Day: entry.Day, File: entry.File, Part: result.Part}
+			if byName[s.Name()] == nil {
+				byName[s.Name()] = &s
+			}
+			series := byName[s.Name()]
+			series.Points = append(series.Points, Point{
+				Time:    entry.Time,
+				Commit:  entry.Commit,
+				Machine: entry.Machine,
+				TotalNs: result.TotalNs(),
+			})
+		}
+	}
+
+	series := make([]Series, 0, len(byName))
+	for _, s := range byName {
+		sort.SliceStable(s.Points, func(a, b int) bool {
+			return s.Points[a].Time.Before(s.Points[b].Time)
+		})
+		series = append(series, *s)
+	}
+	sort.Slice(series, func(a, b int) bool {
+		return series[a].Name() < series[b].Name()
+	})
+	return series
+}
+
+var sparks = []rune("▁▂▃▄▅▆▇█")
+
+// sparkline draws the values as block characters scaled between their
+// minimum and maximum
+func sparkline(values []int64) string {
+	if len(values) == 0 {
+		return ""
+	}
+
+	lo, hi := values[0], values[0]
+	for _, v := range values {
+		lo, hi = min(lo, v), max(hi, v)
+	}
+
+	var b strings.Builder
+	for _, v := range values {
+		level := 0
+		if hi > lo {
+			level = int((v - lo) * int64(len(sparks)-1) / (hi - lo))
+		}
+		b.WriteRune(sparks[level])
+	}
+	return b.String()
+}
+
+func (s Series) values() []int64 {
+	values := make([]int64, len(s.Points))
+	for k, p := range s.Points {
+		values[k] = p.TotalNs
+	}
+	return values
+}
+
+// worstStep finds the run that slowed down the most from the one before,
+// returning its index and the relative change, or -1 if none got slower
+func (s Series) worstStep() (int, float64) {
+	worst, change := -1, 0.0
+	for k := 1; k < len(s.Points); k++ {
+		prev := s.Points[k-1].TotalNs
+		if prev == 0 {
+			continue
+		}
+		if c := float64(s.Points[k].TotalNs-prev) / float64(prev); c > change {
+			worst, change = k, c
+		}
+	}
+	return worst, change
+}
+
+func writeSparklines(w io.Writer, series []Series) {
+	for _, s := range series {
+		values := s.values()
+		lo, hi := values[0], values[0]
+		for _, v := range values {
+			lo, hi = min(lo, v), max(hi, v)
+		}
+		last := s.Points[len(s.Points)-1]
+
+		fmt.Fprintf(w, "%-28s %s  %v  (%d runs, %v - %v)", s.Name(), sparkline(values),
+			time.Duration(last.TotalNs), len(values), time.Duration(lo), time.Duration(hi))
+		if k, change := s.worstStep(); k >= 0 {
+			fmt.Fprintf(w, "  worst step %+.1f%% at %s", change*100, s.Points[k].Commit)
+		}
+		fmt.Fprintln(w)
+	}
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stephen-condon/advent-of-code-2025/utilities"
+)
+
+func TestSparkline(t *testing.T) {
+	if got := sparkline([]int64{10, 20, 80, 45}); got != "▁▂█▄" {
+		t.Errorf("sparkline = %q, want ▁▂█▄", got)
+	}
+	if got := sparkline([]int64{5, 5, 5}); got != "▁▁▁" {
+		t.Errorf("flat sparkline = %q, want ▁▁▁", got)
+	}
+}
+
+func TestWriteHTMLCharts(t *testing.T) {
+	start := time.Date(2025, 12, 8, 0, 0, 0, 0, time.UTC)
+	s := Series{Day: "day8", File: "input.txt", Part: "part one", Points: []Point{
+		{Time: start, Commit: "aaa", Machine: "box <1>", TotalNs: 100},
+		{Time: start.Add(time.Hour), Commit: "bbb", Machine: "box <1>", TotalNs: 200},
+		{Time: start.Add(2 * time.Hour), Commit: "ccc", Machine: "box <1>", TotalNs: 50},
+	}}
+
+	var b strings.Builder
+	if err := writeHTML(&b, []Series{s}); err != nil {
+		t.Fatal(err)
+	}
+	report := b.String()
+
+	// Spread across the width, with the slowest run at the top
+	for _, want := range []string{
+		"<h2>day8 input.txt part one</h2>",
+		`<div class="summary">3 runs, latest 50ns at ccc, worst step &#43;100.0% at bbb</div>`,
+		`<svg width="600" height="120" viewBox="0 0 600 120">`,
+		`<polyline points="8,60 300,8 592,86"/>`,
+		`<circle cx="300" cy="8" r="3"><title>2025-12-08 01:00:00 bbb: 200ns on box &lt;1&gt;</title></circle>`,
+	} {
+		if !strings.Contains(report, want) {
+			t.Errorf("report is missing %s:\n%s", want, report)
+		}
+	}
+	if got := strings.Count(report, "<circle"); got != 3 {
+		t.Errorf("%d dots, want 3", got)
+	}
+}
+
+func TestBuildSeriesFindsWorstStep(t *testing.T) {
+	start := time.Date(2025, 12, 8, 0, 0, 0, 0, time.UTC)
+	run := func(hours int, commit string, ns int64) utilities.HistoryEntry {
+		return utilities.HistoryEntry{
+			Time:   start.Add(time.Duration(hours) * time.Hour),
+			Commit: commit,
+			BenchReport: utilities.BenchReport{Day: "day8", File: "input.txt", Results: []utilities.BenchResult{
+				{Part: "part one", SolveNs: ns},
+			}},
+		}
+	}
+
+	// Out of order on purpose: the series is sorted by time
+	entries := []utilities.HistoryEntry{
+		run(2, "ccc", 200),
+		run(0, "aaa", 100),
+		run(1, "bbb", 110),
+		run(3, "ddd", 190),
+	}
+
+	series := buildSeries(entries, "", "")
+	if len(series) != 1 {
+		t.Fatalf("got %d series, want 1", len(series))
+	}
+	k, change := series[0].worstStep()
+	if commit := series[0].Points[k].Commit; commit != "ccc" {
+		t.Errorf("worst step at %s, want ccc", commit)
+	}
+	if change < 0.81 || change > 0.82 {
+		t.Errorf("worst step %v, want about 0.818", change)
+	}
+
+	if got := buildSeries(entries, "day7", ""); len(got) != 0 {
+		t.Errorf("day filter kept %d series", len(got))
+	}
+}
@@ -0,0 +1,277 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/stephen-condon/advent-of-code-2025/utilities"
+)
+
+// Job is one part of one case of a day, run as its own process
+type Job struct {
+	Day  int
+	Case utilities.Case
+	Part int
+	// Timeout is set when the day has a -timeout flag of its own
+	Timeout bool
+}
+
+func (j Job) Name() string {
+	return fmt.Sprintf("day%d %s part %d", j.Day, j.Case.Name, j.Part)
+}
+
+// Result is what one worker saw running a job
+type Result struct {
+	Worker   int
+	Status   string // ok, MISMATCH, TIMEOUT or FAILED
+	Output   []string
+	Duration time.Duration
+}
+
+func main() {
+	jobs := flag.Int("jobs", runtime.NumCPU(), "number of jobs to run at once")
+	root := flag.String("root", "..", "directory holding the day directories")
+	only := flag.String("days", "", "comma separated days to run, e.g. 2,7 (default all)")
+	timeout := flag.Duration("timeout", 0, "stop a job after this long and report TIMEOUT, 0 for no limit")
+	timings := flag.Bool("timings", true, "show each job's worker and time; turn off for output that can be diffed")
+	flag.Parse()
+
+	days, err := findDays(*root, *only)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	if len(days) == 0 {
+		fmt.Println("No days found")
+		os.Exit(1)
+	}
+
+	binDir, err := os.MkdirTemp("", "run-all")
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	if err := buildDays(*root, binDir, days, *jobs); err != nil {
+		fmt.Println(err)
+		os.RemoveAll(binDir)
+		os.Exit(1)
+	}
+
+	var all []Job
+	for _, day := range days {
+		hasTimeout := acceptsFlag(filepath.Join(binDir, fmt.Sprintf("day%d", day)), "timeout")
+		cases, err := utilities.LoadCases(dayDir(*root, day))
+		if err != nil {
+			fmt.Printf("day%d: %v\n", day, err)
+			os.RemoveAll(binDir)
+			os.Exit(1)
+		}
+		for _, part := range []int{1, 2} {
+			for _, c := range cases {
+				all = append(all, Job{Day: day, Case: c, Part: part, Timeout: hasTimeout})
+			}
+		}
+	}
+
+	// Each worker writes only its own job's slot, so the report comes out
+	// in job order however the jobs finish
+	results := make([]Result, len(all))
+	start := time.Now()
+	runPool(len(all), *jobs, func(worker, item int) {
+		results[item] = runJob(*root, binDir, all[item], *timeout)
+		results[item].Worker = worker
+	})
+	wall := time.Since(start)
+
+	failed := printResults(all, results, wall, *timings)
+	os.RemoveAll(binDir)
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
+
+func dayDir(root string, day int) string {
+	return filepath.Join(root, strconv.Itoa(day))
+}
+
+// findDays lists the numbered directories under root with a main.go,
+// optionally only those in a comma separated list
+func findDays(root, only string) ([]int, error) {
+	wanted := make(map[int]bool)
+	for _, field := range strings.Split(only, ",") {
+		if field = strings.TrimSpace(field); field == "" {
+			continue
+		}
+		day, err := strconv.Atoi(field)
+		if err != nil {
+			return nil, fmt.Errorf("invalid day %q", field)
+		}
+		wanted[day] = true
+	}
+
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return nil, err
+	}
+
+	var days []int
+	for _, entry := range entries {
+		day, err := strconv.Atoi(entry.Name())
+		if err != nil || !entry.IsDir() {
+			continue
+		}
+		if len(wanted) > 0 && !wanted[day] {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(root, entry.Name(), "main.go")); err == nil {
+			days = append(days, day)
+		}
+	}
+	sort.Ints(days)
+	return days, nil
+}
+
+// buildDays compiles every day once, so the jobs don't each pay for it
+func buildDays(root, binDir string, days []int, jobs int) error {
+	errs := make([]error, len(days))
+	runPool(len(days), jobs, func(_, item int) {
+		day := days[item]
+		cmd := exec.Command("go", "build", "-o", filepath.Join(binDir, fmt.Sprintf("day%d", day)), ".")
+		cmd.Dir = dayDir(root, day)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			errs[item] = fmt.Errorf("building day%d: %v\n%s", day, err, out)
+		}
+	})
+	return errors.Join(errs...)
+}
+
+// acceptsFlag reports whether a day's binary lists the flag in its -h usage
+func acceptsFlag(bin, name string) bool {
+	// -h exits non-zero on older Go, but the usage is printed either way
+	out, _ := exec.Command(bin, "-h").CombinedOutput()
+	usage := string(out)
+	return strings.Contains(usage, "  -"+name+" ") || strings.Contains(usage, "  -"+name+"\n")
+}
+
+// killGrace is how long past its own -timeout a day gets to report TIMEOUT
+// before runJob kills it
+const killGrace = time.Second
+
+// runJob runs the day's binary, built once by buildDays, in the day's
+// directory with -only-case and -only-part picking out the job. Running
+// each job as its own process keeps the days' mains and globals apart and
+// lets a stuck part be killed without taking the others with it.
+//
+// Days with a -timeout flag are given the timeout, so they stop the part
+// themselves and say so; the process is only killed if it's still running
+// killGrace later. Other days are killed once the timeout is up.
+func runJob(root, binDir string, job Job, timeout time.Duration) Result {
+	args := []string{"-only-case", job.Case.Name, "-only-part", strconv.Itoa(job.Part)}
+	limit := timeout
+	if job.Timeout && timeout > 0 {
+		args = append(args, "-timeout", timeout.String())
+		limit += killGrace
+	}
+
+	ctx, cancel := utilities.PartContext(limit)
+	defer cancel()
+
+	bin := filepath.Join(binDir, fmt.Sprintf("day%d", job.Day))
+	cmd := exec.CommandContext(ctx, bin, args...)
+	cmd.Dir = dayDir(root, job.Day)
+
+	start := time.Now()
+	out, err := cmd.CombinedOutput()
+	result := Result{Status: "ok", Duration: time.Since(start)}
+
+	for _, line := range strings.Split(string(out), "\n") {
+		// The part headers are the day's, not the job's
+		if strings.TrimSpace(line) == "" || line == "Part One:" || line == "Part Two:" {
+			continue
+		}
+		result.Output = append(result.Output, line)
+
+		switch {
+		case strings.HasSuffix(line, ": TIMEOUT"):
+			result.Status = "TIMEOUT"
+		case strings.Contains(line, "MISMATCH") && result.Status == "ok":
+			result.Status = "MISMATCH"
+		}
+	}
+
+	// Days exit non-zero when a part fails, so an error is only news if
+	// the output hasn't already said what went wrong
+	switch {
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		result.Status = "TIMEOUT"
+	case err != nil && result.Status == "ok":
+		result.Status = "FAILED"
+		result.Output = append(result.Output, err.Error())
+	}
+	return result
+}
+
+// printResults writes each job's output in job order, then how the work was
+// spread over the workers, and returns the number of jobs that weren't ok.
+// Without timings the output is the same from run to run.
+func printResults(jobs []Job, results []Result, wall time.Duration, timings bool) int {
+	failed := 0
+	var total time.Duration
+	busy := make(map[int]time.Duration)
+	count := make(map[int]int)
+
+	for k, job := range jobs {
+		result := results[k]
+		fmt.Printf("%s: %s", job.Name(), result.Status)
+		if timings {
+			fmt.Printf(" (worker %d, %v)", result.Worker, result.Duration.Round(time.Microsecond))
+		}
+		fmt.Println()
+		for _, line := range result.Output {
+			fmt.Printf("  %s\n", line)
+		}
+
+		if result.Status != "ok" {
+			failed++
+		}
+		total += result.Duration
+		busy[result.Worker] += result.Duration
+		count[result.Worker]++
+	}
+
+	if !timings {
+		if failed > 0 {
+			fmt.Printf("\n%d of %d jobs not ok\n", failed, len(jobs))
+		}
+		return failed
+	}
+
+	workers := make([]int, 0, len(busy))
+	for worker := range busy {
+		workers = append(workers, worker)
+	}
+	sort.Ints(workers)
+
+	fmt.Println("\nWorkers:")
+	for _, worker := range workers {
+		fmt.Printf("  worker %d: %d jobs, busy %v\n", worker, count[worker], busy[worker].Round(time.Microsecond))
+	}
+	fmt.Printf("%d jobs in %v, %v if run one after another", len(jobs), wall.Round(time.Microsecond), total.Round(time.Microsecond))
+	if failed > 0 {
+		fmt.Printf(", %d not ok", failed)
+	}
+	fmt.Println()
+
+	return failed
+}
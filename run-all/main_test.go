@@ -0,0 +1,105 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stephen-condon/advent-of-code-2025/utilities"
+)
+
+// scratchDay7 builds the real day 7 and gives it a root of its own, where
+// each case is a grid written by the test
+func scratchDay7(t *testing.T, files map[string]string) (root, binDir string) {
+	t.Helper()
+	if testing.Short() {
+		t.Skip("builds day 7")
+	}
+
+	binDir = t.TempDir()
+	if err := buildDays("..", binDir, []int{7}, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	root = t.TempDir()
+	dir := filepath.Join(root, "7")
+	if err := os.Mkdir(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return root, binDir
+}
+
+func TestRunJobStatuses(t *testing.T) {
+	root, binDir := scratchDay7(t, map[string]string{
+		"day.json": `{"cases": [
+			{"name": "right", "file": "example.txt", "expected": {"partOne": 1}},
+			{"name": "wrong", "file": "example.txt", "expected": {"partOne": 2}},
+			{"name": "nosource", "file": "example2.txt", "expected": {"partOne": 1}},
+			{"name": "unknown", "file": "example2.txt"}
+		]}`,
+		"example.txt":  "..S..\n.....\n..^..\n.....\n",
+		"example2.txt": ".....\n.....\n..^..\n.....\n",
+	})
+	cases, err := utilities.LoadCases(filepath.Join(root, "7"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		status string
+		output string
+	}{
+		"right":    {"ok", "example.txt: 1"},
+		"wrong":    {"MISMATCH", "wrong: MISMATCH, expected 2"},
+		"nosource": {"FAILED", "no source (S) found in the grid"},
+		// A part that fails is a failure even with no answer to check
+		"unknown": {"FAILED", "no source (S) found in the grid"},
+	}
+
+	for _, c := range cases {
+		want, ok := tests[c.Name]
+		if !ok {
+			continue
+		}
+		result := runJob(root, binDir, Job{Day: 7, Case: c, Part: 1}, 0)
+		if result.Status != want.status {
+			t.Errorf("%s: status %s, want %s\n%s", c.Name, result.Status, want.status, strings.Join(result.Output, "\n"))
+		}
+		if output := strings.Join(result.Output, "\n"); !strings.Contains(output, want.output) {
+			t.Errorf("%s: output doesn't mention %q:\n%s", c.Name, want.output, output)
+		}
+	}
+}
+
+func TestRunJobPassesTheTimeoutOn(t *testing.T) {
+	root, binDir := scratchDay7(t, map[string]string{
+		"example.txt": "..S..\n.....\n..^..\n.....\n",
+	})
+	bin := filepath.Join(binDir, "day7")
+	if !acceptsFlag(bin, "timeout") {
+		t.Fatal("day 7 doesn't seem to take -timeout")
+	}
+	if acceptsFlag(bin, "no-such-flag") {
+		t.Error("day 7 seems to take -no-such-flag")
+	}
+
+	// Day 7 runs out of time itself and says so before the kill backstop
+	job := Job{Day: 7, Case: utilities.Case{Name: "example", File: "example.txt"}, Part: 1, Timeout: true}
+	result := runJob(root, binDir, job, time.Nanosecond)
+	if output := strings.Join(result.Output, "\n"); result.Status != "TIMEOUT" || !strings.Contains(output, "example.txt: TIMEOUT") {
+		t.Errorf("status %s, want TIMEOUT reported by day 7:\n%s", result.Status, output)
+	}
+
+	// Without its own -timeout the day is killed
+	job.Timeout = false
+	if result := runJob(root, binDir, job, time.Nanosecond); result.Status != "TIMEOUT" || len(result.Output) != 0 {
+		t.Errorf("status %s, want a silent TIMEOUT:\n%s", result.Status, strings.Join(result.Output, "\n"))
+	}
+}
@@ -0,0 +1,26 @@
+package main
+
+import "sync"
+
+// runPool calls task for items 0..n-1 on at most jobs goroutines, handing
+// items out in order. Each call gets the number of the worker running it,
+// starting at 1.
+func runPool(n, jobs int, task func(worker, item int)) {
+	jobs = max(1, min(jobs, n))
+
+	items := make(chan int)
+	var wg sync.WaitGroup
+	for worker := 1; worker <= jobs; worker++ {
+		wg.Go(func() {
+			for item := range items {
+				task(worker, item)
+			}
+		})
+	}
+
+	for item := 0; item < n; item++ {
+		items <- item
+	}
+	close(items)
+	wg.Wait()
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRunPoolRunsEachItemOnceWithinBound(t *testing.T) {
+	const items, jobs = 50, 4
+
+	var running, peak atomic.Int32
+	var mu sync.Mutex
+	seen := make(map[int]int)
+	workers := make(map[int]bool)
+
+	runPool(items, jobs, func(worker, item int) {
+		now := running.Add(1)
+		for {
+			old := peak.Load()
+			if now <= old || peak.CompareAndSwap(old, now) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		running.Add(-1)
+
+		mu.Lock()
+		seen[item]++
+		workers[worker] = true
+		mu.Unlock()
+	})
+
+	for item := 0; item < items; item++ {
+		if seen[item] != 1 {
+			t.Errorf("item %d ran %d times", item, seen[item])
+		}
+	}
+	if peak.Load() > jobs {
+		t.Errorf("%d items ran at once, want at most %d", peak.Load(), jobs)
+	}
+	for worker := range workers {
+		if worker < 1 || worker > jobs {
+			t.Errorf("worker number %d outside 1..%d", worker, jobs)
+		}
+	}
+}
+
+func TestRunPoolWithMoreWorkersThanItems(t *testing.T) {
+	var count atomic.Int32
+	runPool(2, 16, func(worker, item int) {
+		if worker > 2 {
+			t.Errorf("worker %d started for 2 items", worker)
+		}
+		count.Add(1)
+	})
+	if count.Load() != 2 {
+		t.Errorf("ran %d items, want 2", count.Load())
+	}
+	runPool(0, 4, func(int, int) { t.Error("ran an item with none to run") })
+}
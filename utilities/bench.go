@@ -0,0 +1,271 @@
+package utilities
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"testing"
+	"text/tabwriter"
+	"time"
+)
+
+// Solver solves a part from input that has already been parsed. It must
+// give the same answer every time it's called.
+type Solver func() (string, error)
+
+// BenchPart splits one part of a day into parsing its input and solving
+// it, so the two can be timed separately. Parse must not modify lines.
+type BenchPart struct {
+	Name  string
+	Parse func(lines []string) (Solver, error)
+}
+
+// BenchResult is one part's cost per run. Allocs and Bytes cover parsing
+// and solving together.
+type BenchResult struct {
+	Part    string `json:"part"`
+	Answer  string `json:"answer"`
+	ParseNs int64  `json:"parseNs"`
+	SolveNs int64  `json:"solveNs"`
+	Allocs  int64  `json:"allocs"`
+	Bytes   int64  `json:"bytes"`
+}
+
+// TotalNs is the time to parse and solve the part once
+func (r BenchResult) TotalNs() int64 {
+	return r.ParseNs + r.SolveNs
+}
+
+type BenchReport struct {
+	Day     string        `json:"day"`
+	File    string        `json:"file"`
+	Results []BenchResult `json:"results"`
+}
+
+// parseBench times parsing lines already read from the file, leaving the
+// last solver in solve
+func parseBench(lines []string, part BenchPart, solve *Solver, err *error) func(*testing.B) {
+	return func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			*solve, *err = part.Parse(lines)
+		}
+	}
+}
+
+func solveBench(solve Solver, answer *string, err *error) func(*testing.B) {
+	return func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			*answer, *err = solve()
+		}
+	}
+}
+
+// BenchmarkCase runs "parse" and "solve" sub-benchmarks for part 1 or 2
+// of the file's case, for use from a day's BenchmarkPartOne and
+// BenchmarkPartTwo
+func BenchmarkCase(b *testing.B, filename string, number int, parts func(Case) []BenchPart) {
+	cases, err := LoadCases(".")
+	if err != nil {
+		b.Fatal(err)
+	}
+	part := parts(FindCase(cases, filename))[number-1]
+
+	var solve Solver
+	b.Run("parse", parseBench(LoadInput(filename), part, &solve, &err))
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	var answer string
+	b.Run("solve", solveBench(solve, &answer, &err))
+	if err != nil {
+		b.Fatal(err)
+	}
+}
+
+// RunBench times every part on one file
+func RunBench(day, filename string, parts []BenchPart) (BenchReport, error) {
+	report := BenchReport{Day: day, File: filename}
+	lines := LoadInput(filename)
+
+	for _, part := range parts {
+		var solve Solver
+		var err error
+		parse := testing.Benchmark(parseBench(lines, part, &solve, &err))
+		if err != nil {
+			return report, fmt.Errorf("%s: %v", part.Name, err)
+		}
+
+		var answer string
+		run := testing.Benchmark(solveBench(solve, &answer, &err))
+		if err != nil {
+			return report, fmt.Errorf("%s: %v", part.Name, err)
+		}
+
+		report.Results = append(report.Results, BenchResult{
+			Part:    part.Name,
+			Answer:  answer,
+			ParseNs: parse.NsPerOp(),
+			SolveNs: run.NsPerOp(),
+			Allocs:  parse.AllocsPerOp() + run.AllocsPerOp(),
+			Bytes:   parse.AllocedBytesPerOp() + run.AllocedBytesPerOp(),
+		})
+	}
+
+	return report, nil
+}
+
+// Regressions returns the parts that got slower than the baseline by more
+// than threshold, as a fraction of the baseline's time, keyed by part name
+// with the relative change as the value
+func (r BenchReport) Regressions(baseline BenchReport, threshold float64) map[string]float64 {
+	regressions := make(map[string]float64)
+	for part, change := range r.Changes(baseline) {
+		if change > threshold {
+			regressions[part] = change
+		}
+	}
+	return regressions
+}
+
+// Changes returns each part's change in total time relative to the
+// baseline, for the parts both reports have
+func (r BenchReport) Changes(baseline BenchReport) map[string]float64 {
+	before := make(map[string]int64)
+	for _, result := range baseline.Results {
+		before[result.Part] = result.TotalNs()
+	}
+
+	changes := make(map[string]float64)
+	for _, result := range r.Results {
+		if ns, ok := before[result.Part]; ok && ns > 0 {
+			changes[result.Part] = float64(result.TotalNs()-ns) / float64(ns)
+		}
+	}
+	return changes
+}
+
+// WriteBenchTable writes the report as a table, with the change against
+// the baseline when there is one
+func WriteBenchTable(w io.Writer, report BenchReport, baseline *BenchReport, threshold float64) {
+	fmt.Fprintf(w, "%s %s\n", report.Day, report.File)
+
+	var changes map[string]float64
+	if baseline != nil {
+		changes = report.Changes(*baseline)
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	header := "part\tparse\tsolve\tallocs/op\tbytes/op"
+	if baseline != nil {
+		header += "\tvs baseline"
+	}
+	fmt.Fprintln(tw, header)
+
+	for _, result := range report.Results {
+		fmt.Fprintf(tw, "%s\t%v\t%v\t%d\t%d", result.Part,
+			time.Duration(result.ParseNs), time.Duration(result.SolveNs), result.Allocs, result.Bytes)
+		if baseline != nil {
+			if change, ok := changes[result.Part]; !ok {
+				fmt.Fprint(tw, "\tnew")
+			} else if change > threshold {
+				fmt.Fprintf(tw, "\t%+.1f%% REGRESSION", change*100)
+			} else {
+				fmt.Fprintf(tw, "\t%+.1f%%", change*100)
+			}
+		}
+		fmt.Fprintln(tw)
+	}
+	tw.Flush()
+}
+
+func ReadBenchReport(filename string) (BenchReport, error) {
+	var report BenchReport
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return report, err
+	}
+	if err := json.Unmarshal(data, &report); err != nil {
+		return report, fmt.Errorf("%s: %w", filename, err)
+	}
+	return report, nil
+}
+
+func WriteBenchReport(filename string, report BenchReport) error {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filename, append(data, '\n'), 0o644)
+}
+
+// BenchFlags are the -bench options every day's main accepts
+type BenchFlags struct {
+	bench     bool
+	input     string
+	out       string
+	baseline  string
+	threshold float64
+	history   string
+}
+
+func AddBenchFlags(fs *flag.FlagSet) *BenchFlags {
+	f := &BenchFlags{}
+	fs.BoolVar(&f.bench, "bench", false, "time parsing and solving each part instead of printing the answers")
+	fs.StringVar(&f.input, "bench-input", "input.txt", "input file to benchmark")
+	fs.StringVar(&f.out, "bench-out", "bench.json", "file to save the benchmark results to, empty to not save them")
+	fs.StringVar(&f.baseline, "baseline", "", "earlier benchmark results to compare against")
+	fs.Float64Var(&f.threshold, "threshold", 0.1, "slowdown over the baseline, as a fraction, that counts as a regression")
+	fs.StringVar(&f.history, "history", DefaultHistoryFile(), "JSON Lines file to append the results to, empty to not record them")
+	return f
+}
+
+func (f *BenchFlags) Enabled() bool {
+	return f.bench
+}
+
+// Run benchmarks the case for the -bench-input file, using parts to split
+// each of the day's parts up. It returns an error if any part regressed.
+func (f *BenchFlags) Run(day string, cases []Case, parts func(Case) []BenchPart) error {
+	c := FindCase(cases, f.input)
+	report, err := RunBench(day, c.File, parts(c))
+	if err != nil {
+		return err
+	}
+
+	var baseline *BenchReport
+	if f.baseline != "" {
+		b, err := ReadBenchReport(f.baseline)
+		if err != nil {
+			return err
+		}
+		baseline = &b
+	}
+
+	WriteBenchTable(os.Stdout, report, baseline, f.threshold)
+
+	if f.out != "" {
+		if err := WriteBenchReport(f.out, report); err != nil {
+			return err
+		}
+		fmt.Printf("Wrote %s\n", f.out)
+	}
+
+	if f.history != "" {
+		if err := AppendHistory(f.history, NewHistoryEntry(report)); err != nil {
+			return err
+		}
+		fmt.Printf("Added to %s\n", f.history)
+	}
+
+	if baseline != nil {
+		if regressions := report.Regressions(*baseline, f.threshold); len(regressions) > 0 {
+			return fmt.Errorf("%d part(s) more than %.0f%% slower than %s", len(regressions), f.threshold*100, f.baseline)
+		}
+	}
+	return nil
+}
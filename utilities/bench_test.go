@@ -0,0 +1,96 @@
+package utilities
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRegressionsAboveThreshold(t *testing.T) {
+	baseline := BenchReport{Results: []BenchResult{
+		{Part: "part one", ParseNs: 100, SolveNs: 900},
+		{Part: "part two", ParseNs: 100, SolveNs: 900},
+		{Part: "removed", ParseNs: 100, SolveNs: 100},
+	}}
+	report := BenchReport{Results: []BenchResult{
+		{Part: "part one", ParseNs: 100, SolveNs: 1000}, // 10% slower
+		{Part: "part two", ParseNs: 100, SolveNs: 1200}, // 30% slower
+		{Part: "new", ParseNs: 100, SolveNs: 100},
+	}}
+
+	changes := report.Changes(baseline)
+	if len(changes) != 2 {
+		t.Fatalf("got changes for %d parts, want 2", len(changes))
+	}
+
+	regressions := report.Regressions(baseline, 0.2)
+	if len(regressions) != 1 {
+		t.Fatalf("got %d regressions, want 1", len(regressions))
+	}
+	if change := regressions["part two"]; change < 0.299 || change > 0.301 {
+		t.Errorf("part two changed by %v, want 0.3", change)
+	}
+}
+
+func TestRunBenchReadsTheFileOnce(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "input.txt")
+	if err := os.WriteFile(filename, []byte("1\n2\n3\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Every parse should be handed the same lines, read before timing
+	var first *string
+	parses := 0
+	part := BenchPart{Name: "part one", Parse: func(lines []string) (Solver, error) {
+		if first == nil {
+			first = &lines[0]
+		} else if &lines[0] != first {
+			t.Fatal("parse was given a fresh copy of the input")
+		}
+		parses++
+		return func() (string, error) { return lines[len(lines)-2], nil }, nil
+	}}
+
+	report, err := RunBench("day0", filename, []BenchPart{part})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parses < 2 {
+		t.Errorf("parsed %d times, want the benchmark to repeat it", parses)
+	}
+	if len(report.Results) != 1 || report.Results[0].Answer != "3" {
+		t.Errorf("results = %+v, want one with answer 3", report.Results)
+	}
+}
+
+func TestHistoryDefaultsToTheRepoRoot(t *testing.T) {
+	root, err := filepath.Abs("..")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fs := flag.NewFlagSet("day0", flag.ContinueOnError)
+	f := AddBenchFlags(fs)
+	if err := fs.Parse([]string{"-bench"}); err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join(root, "bench-history.jsonl"); f.history != want {
+		t.Errorf("-bench records to %q, want %q", f.history, want)
+	}
+
+	// The history command shares the file from its own directory
+	t.Chdir(filepath.Join(root, "history"))
+	if got := DefaultHistoryFile(); got != f.history {
+		t.Errorf("default history from history/ is %q, want %q", got, f.history)
+	}
+
+	fs = flag.NewFlagSet("day0", flag.ContinueOnError)
+	f = AddBenchFlags(fs)
+	if err := fs.Parse([]string{"-bench", "-history", ""}); err != nil {
+		t.Fatal(err)
+	}
+	if f.history != "" {
+		t.Errorf("-history \"\" still records to %q", f.history)
+	}
+}
@@ -0,0 +1,118 @@
+package utilities
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Case is one named input for a day, as listed in its day.json:
+//
+//	{
+//	  "cases": [
+//	    {"name": "example", "file": "example.txt", "params": {"connections": 10},
+//	     "expected": {"partOne": 40, "partTwo": 25272}}
+//	  ]
+//	}
+type Case struct {
+	Name     string         `json:"name"`
+	File     string         `json:"file"`
+	Params   map[string]int `json:"params,omitempty"`
+	Expected Expected       `json:"expected"`
+}
+
+// Expected holds a case's known answers; either may be left out
+type Expected struct {
+	PartOne json.Number `json:"partOne,omitempty"`
+	PartTwo json.Number `json:"partTwo,omitempty"`
+}
+
+type dayConfig struct {
+	Cases []Case `json:"cases"`
+}
+
+// Answer returns the expected answer for part 1 or 2, or "" if unknown
+func (c Case) Answer(part int) string {
+	if part == 1 {
+		return c.Expected.PartOne.String()
+	}
+	return c.Expected.PartTwo.String()
+}
+
+// LoadCases reads the cases in dir's day.json, then adds a case with no
+// parameters or answers for every example*.txt and input*.txt file it
+// doesn't mention, so new inputs run just by being dropped in
+func LoadCases(dir string) ([]Case, error) {
+	var config dayConfig
+	data, err := os.ReadFile(filepath.Join(dir, "day.json"))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	if err == nil {
+		if err := json.Unmarshal(data, &config); err != nil {
+			return nil, fmt.Errorf("day.json: %w", err)
+		}
+	}
+
+	listed := make(map[string]bool)
+	for k, c := range config.Cases {
+		if c.File == "" {
+			return nil, fmt.Errorf("day.json: case %d has no file", k+1)
+		}
+		if c.Name == "" {
+			config.Cases[k].Name = strings.TrimSuffix(c.File, ".txt")
+		}
+		listed[c.File] = true
+	}
+
+	var found []string
+	for _, pattern := range []string{"example*.txt", "input*.txt"} {
+		matches, err := filepath.Glob(filepath.Join(dir, pattern))
+		if err != nil {
+			return nil, err
+		}
+		for _, match := range matches {
+			if file := filepath.Base(match); !listed[file] {
+				found = append(found, file)
+			}
+		}
+	}
+	sort.Strings(found)
+
+	for _, file := range found {
+		config.Cases = append(config.Cases, Case{Name: strings.TrimSuffix(file, ".txt"), File: file})
+	}
+	return config.Cases, nil
+}
+
+// FindCase returns the first case for the file, or a bare case for it if
+// there isn't one
+func FindCase(cases []Case, filename string) Case {
+	for _, c := range cases {
+		if c.File == filename {
+			return c
+		}
+	}
+	return Case{Name: strings.TrimSuffix(filename, ".txt"), File: filename}
+}
+
+// CheckAnswer prints a line under the answer if it doesn't match the one
+// the case expects for the part, and reports whether it matched. An empty
+// answer means the part failed and already reported why, such as with a
+// TIMEOUT line, so it doesn't match but isn't reported again.
+func CheckAnswer(c Case, part int, answer string) bool {
+	want := c.Answer(part)
+	if want == "" || answer == want {
+		return true
+	}
+	if answer == "" {
+		return false
+	}
+	fmt.Printf("  %s: MISMATCH, expected %s\n", c.Name, want)
+	return false
+}
@@ -0,0 +1,113 @@
+package utilities
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadCasesAddsUnlistedInputs(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"day.json": `{"cases": [
+			{"name": "small", "file": "input.txt", "params": {"connections": 10}, "expected": {"partOne": 40}},
+			{"file": "example.txt"}
+		]}`,
+		"example.txt":  "",
+		"example2.txt": "",
+		"input.txt":    "",
+		"input_b.txt":  "",
+		"notes.txt":    "",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cases, err := LoadCases(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"small:input.txt", "example:example.txt", "example2:example2.txt", "input_b:input_b.txt"}
+	if len(cases) != len(want) {
+		t.Fatalf("got %d cases, want %d", len(cases), len(want))
+	}
+	for k, c := range cases {
+		if got := c.Name + ":" + c.File; got != want[k] {
+			t.Errorf("case %d is %s, want %s", k, got, want[k])
+		}
+	}
+
+	if got, ok := cases[0].Params["connections"]; !ok || got != 10 {
+		t.Errorf("connections = %d, want 10", got)
+	}
+	if _, ok := cases[1].Params["connections"]; ok {
+		t.Errorf("unlisted case has params %v, want none", cases[1].Params)
+	}
+	if got := cases[0].Answer(1); got != "40" {
+		t.Errorf("part one answer = %q, want 40", got)
+	}
+	if got := cases[0].Answer(2); got != "" {
+		t.Errorf("part two answer = %q, want none", got)
+	}
+}
+
+func TestLoadCasesWithoutConfig(t *testing.T) {
+	cases, err := LoadCases(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cases) != 0 {
+		t.Errorf("got %d cases from an empty directory", len(cases))
+	}
+}
+
+// captureStdout returns what fn prints
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = stdout }()
+
+	fn()
+	w.Close()
+	out, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(out)
+}
+
+func TestCheckAnswer(t *testing.T) {
+	known := Case{Name: "example", Expected: Expected{PartOne: "40"}}
+	unknown := Case{Name: "input"}
+
+	tests := []struct {
+		c       Case
+		answer  string
+		want    bool
+		printed string
+	}{
+		{known, "40", true, ""},
+		{known, "41", false, "  example: MISMATCH, expected 40\n"},
+		// A part that failed has already said why, but still didn't match
+		{known, "", false, ""},
+		{unknown, "41", true, ""},
+		{unknown, "", true, ""},
+	}
+
+	for _, tt := range tests {
+		var got bool
+		printed := captureStdout(t, func() { got = CheckAnswer(tt.c, 1, tt.answer) })
+		if got != tt.want || printed != tt.printed {
+			t.Errorf("CheckAnswer(%s, %q) = %v printing %q, want %v printing %q", tt.c.Name, tt.answer, got, printed, tt.want, tt.printed)
+		}
+	}
+}
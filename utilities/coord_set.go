@@ -0,0 +1,63 @@
+package utilities
+
+import "fmt"
+
+// CoordSet is a bitset of (row, col, state) triples inside a fixed box,
+// for grid searches that would otherwise key a map with formatted strings.
+// The box covers rows minRow..minRow+rows-1 and cols minCol..minCol+cols-1;
+// state is a small tag such as a direction, 0..states-1.
+type CoordSet struct {
+	minRow, minCol     int
+	rows, cols, states int
+	bits               []uint64
+	count              int
+}
+
+func NewCoordSet(minRow, minCol, rows, cols, states int) *CoordSet {
+	size := rows * cols * states
+	return &CoordSet{
+		minRow: minRow,
+		minCol: minCol,
+		rows:   rows,
+		cols:   cols,
+		states: states,
+		bits:   make([]uint64, (size+63)/64),
+	}
+}
+
+// Index maps a triple to its position in 0..Size()-1, so callers can keep
+// per-state values in a plain slice alongside the set
+func (s *CoordSet) Index(row, col, state int) int {
+	r, c := row-s.minRow, col-s.minCol
+	if r < 0 || r >= s.rows || c < 0 || c >= s.cols || state < 0 || state >= s.states {
+		panic(fmt.Sprintf("CoordSet: (%d, %d, %d) outside the set's bounds", row, col, state))
+	}
+	return (r*s.cols+c)*s.states + state
+}
+
+// Size is the number of triples the set can hold
+func (s *CoordSet) Size() int {
+	return s.rows * s.cols * s.states
+}
+
+// Add inserts the triple and reports whether it was not already present
+func (s *CoordSet) Add(row, col, state int) bool {
+	i := s.Index(row, col, state)
+	word, bit := i/64, uint64(1)<<(i%64)
+	if s.bits[word]&bit != 0 {
+		return false
+	}
+	s.bits[word] |= bit
+	s.count++
+	return true
+}
+
+func (s *CoordSet) Has(row, col, state int) bool {
+	i := s.Index(row, col, state)
+	return s.bits[i/64]&(uint64(1)<<(i%64)) != 0
+}
+
+// Len is the number of triples in the set
+func (s *CoordSet) Len() int {
+	return s.count
+}
@@ -0,0 +1,106 @@
+package utilities
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCoordSetAddHasLen(t *testing.T) {
+	// A box with a one-cell margin round a 3x4 grid, four states per cell
+	s := NewCoordSet(-1, -1, 5, 6, 4)
+	if s.Size() != 5*6*4 {
+		t.Errorf("Size = %d, want %d", s.Size(), 5*6*4)
+	}
+
+	if !s.Add(0, 0, 2) {
+		t.Error("first Add(0, 0, 2) reported the triple as present")
+	}
+	if s.Add(0, 0, 2) {
+		t.Error("second Add(0, 0, 2) reported the triple as new")
+	}
+	s.Add(-1, -1, 0)
+	s.Add(3, 4, 3)
+
+	for _, tt := range []struct {
+		row, col, state int
+		want            bool
+	}{
+		{0, 0, 2, true},
+		{0, 0, 1, false},
+		{0, 0, 3, false},
+		{-1, -1, 0, true},
+		{3, 4, 3, true},
+		{3, 4, 2, false},
+		{1, 1, 0, false},
+	} {
+		if got := s.Has(tt.row, tt.col, tt.state); got != tt.want {
+			t.Errorf("Has(%d, %d, %d) = %v, want %v", tt.row, tt.col, tt.state, got, tt.want)
+		}
+	}
+
+	if s.Len() != 3 {
+		t.Errorf("Len = %d, want 3", s.Len())
+	}
+}
+
+func TestCoordSetIndexIsDense(t *testing.T) {
+	s := NewCoordSet(2, -3, 3, 5, 2)
+	seen := make(map[int]bool)
+	for row := 2; row < 5; row++ {
+		for col := -3; col < 2; col++ {
+			for state := 0; state < 2; state++ {
+				i := s.Index(row, col, state)
+				if i < 0 || i >= s.Size() || seen[i] {
+					t.Fatalf("Index(%d, %d, %d) = %d, not a fresh index below %d", row, col, state, i, s.Size())
+				}
+				seen[i] = true
+			}
+		}
+	}
+}
+
+func TestCoordSetSparseAndLarge(t *testing.T) {
+	const side = 10000
+	s := NewCoordSet(0, 0, side, side, 1)
+
+	// Touch cells either side of word boundaries and the far corner
+	cells := [][2]int{{0, 0}, {0, 63}, {0, 64}, {1, 0}, {4999, 5001}, {side - 1, side - 1}}
+	for _, c := range cells {
+		s.Add(c[0], c[1], 0)
+	}
+	if s.Len() != len(cells) {
+		t.Errorf("Len = %d, want %d", s.Len(), len(cells))
+	}
+	for _, c := range cells {
+		if !s.Has(c[0], c[1], 0) {
+			t.Errorf("Has(%d, %d, 0) = false after Add", c[0], c[1])
+		}
+	}
+	for _, c := range [][2]int{{0, 1}, {0, 62}, {0, 65}, {side - 1, side - 2}, {side - 2, side - 1}} {
+		if s.Has(c[0], c[1], 0) {
+			t.Errorf("Has(%d, %d, 0) = true, never added", c[0], c[1])
+		}
+	}
+}
+
+func TestCoordSetIndexPanicsOutOfBounds(t *testing.T) {
+	s := NewCoordSet(-1, -1, 3, 3, 2)
+	outside := [][3]int{
+		{-2, 0, 0}, {2, 0, 0}, // rows
+		{0, -2, 0}, {0, 2, 0}, // cols
+		{0, 0, -1}, {0, 0, 2}, // states
+	}
+
+	for _, o := range outside {
+		func() {
+			defer func() {
+				r := recover()
+				msg, _ := r.(string)
+				if !strings.Contains(msg, "outside the set's bounds") {
+					t.Errorf("Index(%d, %d, %d) recovered %v, want an out of bounds panic", o[0], o[1], o[2], r)
+				}
+			}()
+			s.Index(o[0], o[1], o[2])
+		}()
+	}
+}
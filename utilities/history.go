@@ -0,0 +1,125 @@
+package utilities
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// HistoryEntry is one -bench run, as a line of the history file
+type HistoryEntry struct {
+	Time      time.Time `json:"time"`
+	Commit    string    `json:"commit"`
+	GoVersion string    `json:"goVersion"`
+	Machine   string    `json:"machine"`
+	BenchReport
+}
+
+// NewHistoryEntry tags a report with the current commit, Go version and
+// machine
+func NewHistoryEntry(report BenchReport) HistoryEntry {
+	return HistoryEntry{
+		Time:        time.Now().UTC(),
+		Commit:      gitCommit(),
+		GoVersion:   runtime.Version(),
+		Machine:     machine(),
+		BenchReport: report,
+	}
+}
+
+// DefaultHistoryFile is bench-history.jsonl at the root of the repo, so
+// every day and the history command share it whichever directory they're
+// run from
+func DefaultHistoryFile() string {
+	return filepath.Join(repoRoot(), "bench-history.jsonl")
+}
+
+// repoRoot walks up from the working directory to the one holding the
+// utilities module, falling back to the working directory itself
+func repoRoot() string {
+	wd, err := os.Getwd()
+	if err != nil {
+		return "."
+	}
+	for dir := wd; ; dir = filepath.Dir(dir) {
+		if _, err := os.Stat(filepath.Join(dir, "utilities", "go.mod")); err == nil {
+			return dir
+		}
+		if filepath.Dir(dir) == dir {
+			return wd
+		}
+	}
+}
+
+// gitCommit is the short hash of HEAD, marked dirty if the tree has
+// uncommitted changes, or "unknown" outside a git checkout
+func gitCommit() string {
+	out, err := exec.Command("git", "rev-parse", "--short", "HEAD").Output()
+	if err != nil {
+		return "unknown"
+	}
+	commit := strings.TrimSpace(string(out))
+
+	status, err := exec.Command("git", "status", "--porcelain", "--untracked-files=no").Output()
+	if err == nil && len(status) > 0 {
+		commit += "-dirty"
+	}
+	return commit
+}
+
+func machine() string {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown"
+	}
+	return fmt.Sprintf("%s %s/%s %d CPUs", host, runtime.GOOS, runtime.GOARCH, runtime.NumCPU())
+}
+
+// AppendHistory adds an entry to the end of a JSON Lines history file,
+// creating it if needed
+func AppendHistory(filename string, entry HistoryEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(filename, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// ReadHistory reads every entry in a history file, oldest first
+func ReadHistory(filename string) ([]HistoryEntry, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var entries []HistoryEntry
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, 1<<20)
+	for line := 1; scanner.Scan(); line++ {
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+		var entry HistoryEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", filename, line, err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, scanner.Err()
+}
@@ -0,0 +1,106 @@
+package utilities
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/pprof"
+	"runtime/trace"
+)
+
+// ProfileFlags are the profiling options every day's main accepts. Each
+// profiled part gets its own files, named <day>-<case>-part<N>.<kind>.
+type ProfileFlags struct {
+	cpu, mem, trace, block bool
+	dir                    string
+	part                   int
+}
+
+func AddProfileFlags(fs *flag.FlagSet) *ProfileFlags {
+	p := &ProfileFlags{}
+	fs.BoolVar(&p.cpu, "cpuprofile", false, "write a CPU profile of each part")
+	fs.BoolVar(&p.mem, "memprofile", false, "write a heap profile after each part")
+	fs.BoolVar(&p.trace, "trace", false, "write an execution trace of each part")
+	fs.BoolVar(&p.block, "blockprofile", false, "write a blocking profile of each part")
+	fs.StringVar(&p.dir, "profile-dir", ".", "directory to write profiles to")
+	fs.IntVar(&p.part, "profile-part", 0, "only profile this part (1 or 2), 0 for both")
+	return p
+}
+
+func (p *ProfileFlags) enabled(part int) bool {
+	return (p.cpu || p.mem || p.trace || p.block) && (p.part == 0 || p.part == part)
+}
+
+func (p *ProfileFlags) path(day string, c Case, part int, kind string) string {
+	return filepath.Join(p.dir, fmt.Sprintf("%s-%s-part%d.%s", day, c.Name, part, kind))
+}
+
+// Run calls solve for one part of a case, profiling it as the flags ask,
+// and returns its answer. Profiles that can't be written are reported but
+// don't stop the part from running.
+func (p *ProfileFlags) Run(day string, c Case, part int, solve func() string) string {
+	if !p.enabled(part) {
+		return solve()
+	}
+	if err := os.MkdirAll(p.dir, 0o755); err != nil {
+		fmt.Println(err)
+		return solve()
+	}
+
+	var stops []func() error
+	start := func(kind string, begin func(f *os.File) error, end func(f *os.File) error) {
+		f, err := os.Create(p.path(day, c, part, kind))
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		if err := begin(f); err != nil {
+			fmt.Println(err)
+			f.Close()
+			return
+		}
+		stops = append(stops, func() error {
+			err := end(f)
+			if closeErr := f.Close(); err == nil {
+				err = closeErr
+			}
+			if err == nil {
+				fmt.Printf("  wrote %s\n", f.Name())
+			}
+			return err
+		})
+	}
+
+	if p.cpu {
+		start("cpu.pprof", func(f *os.File) error { return pprof.StartCPUProfile(f) },
+			func(*os.File) error { pprof.StopCPUProfile(); return nil })
+	}
+	if p.trace {
+		start("trace.out", func(f *os.File) error { return trace.Start(f) },
+			func(*os.File) error { trace.Stop(); return nil })
+	}
+	if p.block {
+		start("block.pprof", func(*os.File) error { runtime.SetBlockProfileRate(1); return nil },
+			func(f *os.File) error {
+				runtime.SetBlockProfileRate(0)
+				return pprof.Lookup("block").WriteTo(f, 0)
+			})
+	}
+	if p.mem {
+		start("mem.pprof", func(*os.File) error { return nil }, func(f *os.File) error {
+			runtime.GC() // so the heap profile is up to date
+			return pprof.WriteHeapProfile(f)
+		})
+	}
+
+	answer := solve()
+
+	for _, stop := range stops {
+		if err := stop(); err != nil {
+			fmt.Println(err)
+		}
+	}
+	return answer
+}
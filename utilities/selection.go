@@ -0,0 +1,36 @@
+package utilities
+
+import "flag"
+
+// Selection narrows a day's run down to one case or part, so that each
+// case and part can be run as a separate job
+type Selection struct {
+	caseName string
+	part     int
+}
+
+func AddSelectionFlags(fs *flag.FlagSet) *Selection {
+	s := &Selection{}
+	fs.StringVar(&s.caseName, "only-case", "", "only run the case with this name")
+	fs.IntVar(&s.part, "only-part", 0, "only run this part (1 or 2), 0 for both")
+	return s
+}
+
+// Cases returns the cases to run for part 1 or 2, which is none if the
+// part isn't selected
+func (s *Selection) Cases(cases []Case, part int) []Case {
+	if s.part != 0 && s.part != part {
+		return nil
+	}
+	if s.caseName == "" {
+		return cases
+	}
+
+	var selected []Case
+	for _, c := range cases {
+		if c.Name == s.caseName {
+			selected = append(selected, c)
+		}
+	}
+	return selected
+}
@@ -0,0 +1,30 @@
+package utilities
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// PartContext returns the context to run one part in, cancelled after
+// timeout, or never if timeout is 0
+func PartContext(timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(context.Background())
+	}
+	return context.WithTimeout(context.Background(), timeout)
+}
+
+// PrintPartError reports why a part has no answer, with a TIMEOUT status
+// if it ran out of time
+func PrintPartError(filename string, err error) {
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		fmt.Printf("%s: TIMEOUT\n", filename)
+	case errors.Is(err, context.Canceled):
+		fmt.Printf("%s: CANCELLED\n", filename)
+	default:
+		fmt.Printf("%s: %v\n", filename, err)
+	}
+}